@@ -0,0 +1,81 @@
+package applicability
+
+import (
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// AnnotatedVulnerability is a services.Vulnerability with its CVEs' contextual applicability
+// statuses attached, for output formats (like JSON) that can't otherwise surface applicability
+// since xrutils.PrintScanResults has no notion of it.
+type AnnotatedVulnerability struct {
+	services.Vulnerability
+	Applicability map[string]Status `json:"applicability,omitempty"`
+}
+
+// AnnotatedViolation is the services.Violation counterpart of AnnotatedVulnerability.
+type AnnotatedViolation struct {
+	services.Violation
+	Applicability map[string]Status `json:"applicability,omitempty"`
+}
+
+// AnnotatedScanResponse is a services.ScanResponse with every vulnerability and violation
+// annotated with its CVEs' applicability statuses. It embeds the full response (rather than
+// picking out a handful of fields) so Licenses and every other field existing JSON consumers rely
+// on survive untouched; only Vulnerabilities and Violations are shadowed with their annotated
+// counterparts.
+type AnnotatedScanResponse struct {
+	services.ScanResponse
+	Vulnerabilities []AnnotatedVulnerability `json:"vulnerabilities,omitempty"`
+	Violations      []AnnotatedViolation     `json:"violations,omitempty"`
+}
+
+// Annotate merges flatResults with the applicability statuses computed for the artifacts
+// targetPaths maps them to, returning a JSON-friendly structure that carries applicability
+// alongside each vulnerability and violation instead of dropping it on the floor.
+func Annotate(flatResults []services.ScanResponse, targetPaths map[string]string, applicabilityResults Results) []AnnotatedScanResponse {
+	annotated := make([]AnnotatedScanResponse, 0, len(flatResults))
+	for _, res := range flatResults {
+		statuses := applicabilityResults[targetPaths[res.ScanId]]
+
+		vulnerabilities := make([]AnnotatedVulnerability, 0, len(res.Vulnerabilities))
+		for _, vuln := range res.Vulnerabilities {
+			vulnerabilities = append(vulnerabilities, AnnotatedVulnerability{
+				Vulnerability: vuln,
+				Applicability: cveApplicability(statuses, vuln.Cves),
+			})
+		}
+
+		violations := make([]AnnotatedViolation, 0, len(res.Violations))
+		for _, violation := range res.Violations {
+			violations = append(violations, AnnotatedViolation{
+				Violation:     violation,
+				Applicability: cveApplicability(statuses, violation.Cves),
+			})
+		}
+
+		annotated = append(annotated, AnnotatedScanResponse{
+			ScanResponse:    res,
+			Vulnerabilities: vulnerabilities,
+			Violations:      violations,
+		})
+	}
+	return annotated
+}
+
+// cveApplicability picks out of statuses just the entries relevant to cves, so each annotated
+// vulnerability/violation only carries the applicability data for its own CVEs.
+func cveApplicability(statuses CveApplicability, cves []services.Cve) map[string]Status {
+	if len(statuses) == 0 || len(cves) == 0 {
+		return nil
+	}
+	result := make(map[string]Status)
+	for _, cve := range cves {
+		if status, ok := statuses[cve.Id]; ok {
+			result[cve.Id] = status
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
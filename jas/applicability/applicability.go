@@ -0,0 +1,202 @@
+package applicability
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gofrogio "github.com/jfrog/gofrog/io"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/commands"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// Status describes the outcome of a contextual applicability check for a single CVE.
+type Status string
+
+const (
+	Applicable    Status = "Applicable"
+	NotApplicable Status = "Not Applicable"
+	Undetermined  Status = "Undetermined"
+
+	analyzerManagerCommand = "ca"
+
+	// applicabilityFeatureId is the Xray/JAS entitlement checked before running the analyzer-manager,
+	// the same feature id the connected Xray instance reports under its entitlements API.
+	applicabilityFeatureId = "contextual_analysis"
+
+	// applicabilityMinXrayVersion is the oldest Xray version that ships contextual applicability
+	// analysis. Older servers are treated the same as unentitled ones: the phase is skipped rather
+	// than failed.
+	applicabilityMinXrayVersion = "3.66.5"
+)
+
+// CveApplicability maps a CVE id to its computed applicability status for a single scanned target.
+type CveApplicability map[string]Status
+
+// Results maps a scanned target (the path of the artifact that was indexed) to the applicability
+// statuses of the CVEs found in it.
+type Results map[string]CveApplicability
+
+// scannersWithApplicabilitySupport is the set of component id schemes (the part of a component id
+// before "://", e.g. "npm" in "npm://foo:1.2.3" - see purlToComponentId) the analyzer-manager ships
+// an applicability rule set for. CVEs affecting components from other ecosystems are left
+// Undetermined, since running the analyzer-manager on them would never produce a real verdict.
+var scannersWithApplicabilitySupport = map[string]bool{
+	"npm":   true,
+	"pypi":  true,
+	"gav":   true,
+	"cargo": true,
+}
+
+// Scan unpacks every scanned artifact that has known applicability scanners and runs the
+// analyzer-manager against the CVEs Xray flagged in it, returning a per-target CVE applicability
+// map. targetPaths maps an Xray scan id (services.ScanResponse.ScanId) to the local path of the
+// artifact that produced it, so extraction knows what to unpack. The phase only runs when the connected
+// Xray/JAS is entitled for it; unentitled or too-old servers get an empty result rather than a
+// wasted analyzer-manager download.
+func Scan(flatResults []services.ScanResponse, targetPaths map[string]string, serverDetails *config.ServerDetails, xrayVersion string) (Results, error) {
+	entitled, err := isEntitledForApplicability(serverDetails, xrayVersion)
+	if err != nil {
+		return nil, err
+	}
+	if !entitled {
+		log.Debug("Contextual applicability analysis skipped: the connected Xray isn't entitled for it.")
+		return nil, nil
+	}
+
+	analyzerManagerPath, err := DownloadAnalyzerManagerIfNeeded()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(Results)
+	for _, scanResponse := range flatResults {
+		targetPath, ok := targetPaths[scanResponse.ScanId]
+		if !ok {
+			continue
+		}
+		cves := relevantCves(scanResponse)
+		if len(cves) == 0 {
+			continue
+		}
+		roots, cleanup, err := extractRoots(targetPath)
+		if err != nil {
+			log.Warn(fmt.Sprintf("Skipping applicability scan for %s: %s", targetPath, err.Error()))
+			continue
+		}
+		statuses, err := runAnalyzerManager(analyzerManagerPath, roots, cves)
+		cleanup()
+		if err != nil {
+			return nil, err
+		}
+		results[targetPath] = statuses
+	}
+	return results, nil
+}
+
+// relevantCves filters the CVEs in a scan response down to the ones affecting a component from an
+// ecosystem with a known applicability scanner, since running the analyzer-manager on the rest
+// would only ever yield Undetermined.
+func relevantCves(scanResponse services.ScanResponse) []string {
+	seen := make(map[string]bool)
+	var cves []string
+	addCve := func(id string) {
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		cves = append(cves, id)
+	}
+	for _, vuln := range scanResponse.Vulnerabilities {
+		if !hasApplicabilitySupport(vuln.Components) {
+			continue
+		}
+		for _, cve := range vuln.Cves {
+			if cve.Id != "" {
+				addCve(cve.Id)
+			}
+		}
+	}
+	for _, violation := range scanResponse.Violations {
+		if !hasApplicabilitySupport(violation.Components) {
+			continue
+		}
+		for _, cve := range violation.Cves {
+			if cve.Id != "" {
+				addCve(cve.Id)
+			}
+		}
+	}
+	return cves
+}
+
+// hasApplicabilitySupport reports whether at least one of the given components belongs to an
+// ecosystem the analyzer-manager ships an applicability rule set for.
+func hasApplicabilitySupport(components map[string]services.Component) bool {
+	for componentId := range components {
+		if scannersWithApplicabilitySupport[componentScheme(componentId)] {
+			return true
+		}
+	}
+	return false
+}
+
+// isEntitledForApplicability reports whether the connected Xray/JAS instance is entitled to run
+// contextual applicability analysis, so Scan can skip the analyzer-manager download and execution
+// entirely for servers that are too old or don't have the JAS add-on.
+func isEntitledForApplicability(serverDetails *config.ServerDetails, xrayVersion string) (bool, error) {
+	if err := commands.ValidateXrayMinimumVersion(xrayVersion, applicabilityMinXrayVersion); err != nil {
+		return false, nil
+	}
+	xrayManager, _, err := commands.CreateXrayServiceManagerAndGetVersion(serverDetails)
+	if err != nil {
+		return false, err
+	}
+	entitled, err := xrayManager.IsEntitled(applicabilityFeatureId)
+	if err != nil {
+		return false, errorutils.CheckErrorf("failed checking JAS entitlement: %s", err.Error())
+	}
+	return entitled, nil
+}
+
+// componentScheme returns the scheme prefix of a component id, e.g. "npm" for
+// "npm://foo:1.2.3", matching the format purlToComponentId builds.
+func componentScheme(componentId string) string {
+	scheme, _, ok := strings.Cut(componentId, "://")
+	if !ok {
+		return ""
+	}
+	return scheme
+}
+
+// runAnalyzerManager writes the scan config, invokes the analyzer-manager binary and parses its
+// SARIF report into per-CVE applicability statuses.
+func runAnalyzerManager(analyzerManagerPath string, roots []string, cves []string) (CveApplicability, error) {
+	tmpDir, err := os.MkdirTemp("", "jfrog-applicability")
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err = writeConfig(configPath, roots, cves); err != nil {
+		return nil, err
+	}
+	sarifPath := filepath.Join(tmpDir, "results.sarif")
+
+	execCmd := &coreutils.GeneralExecCmd{
+		ExecPath: analyzerManagerPath,
+		Command:  []string{analyzerManagerCommand, configPath, sarifPath},
+	}
+	if _, err = gofrogio.RunCmdOutput(execCmd); err != nil {
+		return nil, errorutils.CheckErrorf("analyzer-manager applicability scan failed: %s", err.Error())
+	}
+	return parseSarif(sarifPath, cves)
+}
@@ -0,0 +1,29 @@
+package applicability
+
+import (
+	"os"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v3"
+)
+
+// scanConfig is the YAML input the analyzer-manager expects: the source roots to scan and the
+// CVEs to check applicability for.
+type scanConfig struct {
+	Scans []scanConfigEntry `yaml:"scans"`
+}
+
+type scanConfigEntry struct {
+	Roots []string `yaml:"roots"`
+	Cves  []string `yaml:"cves"`
+	Type  string   `yaml:"type"`
+}
+
+func writeConfig(configPath string, roots []string, cves []string) error {
+	config := scanConfig{Scans: []scanConfigEntry{{Roots: roots, Cves: cves, Type: "applicability"}}}
+	content, err := yaml.Marshal(config)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(configPath, content, 0644))
+}
@@ -0,0 +1,45 @@
+package applicability
+
+import (
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	analyzerManagerDirName  = "analyzerManager"
+	analyzerManagerFileName = "analyzerManager"
+	analyzerManagerReleases = "https://releases.jfrog.io/artifactory/xsc-gen-exe-analyzer-manager-local"
+)
+
+// DownloadAnalyzerManagerIfNeeded downloads the analyzer-manager binary into the local
+// dependencies directory, the same way xrutils.DownloadIndexerIfNeeded manages the Xray indexer,
+// and returns its path on the local file system. If the binary already exists locally, the
+// download is skipped.
+func DownloadAnalyzerManagerIfNeeded() (string, error) {
+	dependenciesDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	analyzerManagerPath := filepath.Join(dependenciesDir, "dependencies", analyzerManagerDirName, coreutils.GetExecutableName(analyzerManagerFileName))
+
+	exists, err := fileutils.IsFileExists(analyzerManagerPath, false)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return analyzerManagerPath, nil
+	}
+	log.Info("Downloading the analyzer-manager for the first time. This may take a few seconds...")
+	downloadUrl := analyzerManagerReleases + "/" + coreutils.GetOsAndArc() + "/" + coreutils.GetExecutableName(analyzerManagerFileName)
+	if err = fileutils.DownloadFile(analyzerManagerPath, downloadUrl); err != nil {
+		return "", errorutils.CheckErrorf("failed downloading the analyzer-manager: %s", err.Error())
+	}
+	if err = fileutils.MakeExecutable(analyzerManagerPath); err != nil {
+		return "", err
+	}
+	return analyzerManagerPath, nil
+}
@@ -0,0 +1,172 @@
+package applicability
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// archiveExtensions maps the file extensions applicability extraction knows how to unpack to
+// their archive kind. Jars, npm tarballs and wheels are all zip/tar under the hood.
+var archiveExtensions = map[string]string{
+	".jar":    "zip",
+	".war":    "zip",
+	".whl":    "zip",
+	".tgz":    "tar.gz",
+	".tar.gz": "tar.gz",
+}
+
+// extractRoots unpacks targetPath into a temporary directory so the analyzer-manager can run its
+// source-level applicability checks against the actual file contents, and returns the resulting
+// root directories together with a cleanup function that removes them.
+func extractRoots(targetPath string) ([]string, func(), error) {
+	kind, ok := archiveKind(targetPath)
+	if !ok {
+		// Not an archive format applicability knows how to unpack (e.g. a native binary) -
+		// scan the artifact's own directory as-is.
+		return []string{filepath.Dir(targetPath)}, func() {}, nil
+	}
+
+	extractDir, err := os.MkdirTemp("", "jfrog-applicability-extract")
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	cleanup := func() { _ = os.RemoveAll(extractDir) }
+
+	var extractErr error
+	switch kind {
+	case "zip":
+		extractErr = extractZip(targetPath, extractDir)
+	case "tar.gz":
+		extractErr = extractTarGz(targetPath, extractDir)
+	}
+	if extractErr != nil {
+		cleanup()
+		return nil, nil, extractErr
+	}
+	return []string{extractDir}, cleanup, nil
+}
+
+func archiveKind(path string) (string, bool) {
+	lowered := strings.ToLower(path)
+	for ext, kind := range archiveExtensions {
+		if strings.HasSuffix(lowered, ext) {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+func extractZip(archivePath, destDir string) error {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	for _, entry := range reader.File {
+		if err = extractZipEntry(entry, destDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipEntry(entry *zip.File, destDir string) error {
+	targetPath, err := SafeJoin(destDir, entry.Name)
+	if err != nil {
+		return err
+	}
+	if entry.FileInfo().IsDir() {
+		return errorutils.CheckError(os.MkdirAll(targetPath, 0755))
+	}
+	if err = os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	src, err := entry.Open()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+	dst, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, entry.Mode())
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = dst.Close()
+	}()
+	_, err = io.Copy(dst, src)
+	return errorutils.CheckError(err)
+}
+
+func extractTarGz(archivePath, destDir string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = gzipReader.Close()
+	}()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		targetPath, err := SafeJoin(destDir, header.Name)
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(targetPath, 0755); err != nil {
+				return errorutils.CheckError(err)
+			}
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+				return errorutils.CheckError(err)
+			}
+			outFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+			_, copyErr := io.Copy(outFile, tarReader)
+			_ = outFile.Close()
+			if copyErr != nil {
+				return errorutils.CheckError(copyErr)
+			}
+		}
+	}
+}
+
+// SafeJoin joins destDir with an archive entry name while rejecting entries that would escape
+// destDir (Zip Slip), which untrusted SBOM/artifact archives could otherwise exploit.
+func SafeJoin(destDir, entryName string) (string, error) {
+	targetPath := filepath.Join(destDir, entryName)
+	if !strings.HasPrefix(targetPath, filepath.Clean(destDir)+string(os.PathSeparator)) {
+		return "", errorutils.CheckErrorf("illegal file path in archive: %s", entryName)
+	}
+	return targetPath, nil
+}
@@ -0,0 +1,49 @@
+package applicability
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// PrintTable renders the applicability status computed for each scanned target's CVEs as an
+// aligned CVE/Status/Target table, immediately following the vulnerability/violation table
+// xrutils.PrintScanResults already printed for Table output - xrutils.PrintScanResults itself lives
+// outside this module and has no notion of applicability, so this is the closest this package can
+// fold the status into the table view rather than a free-form log block.
+func PrintTable(results Results) {
+	if len(results) == 0 {
+		return
+	}
+	log.Info("Contextual applicability analysis:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CVE\tStatus\tTarget")
+	for _, target := range sortedTargets(results) {
+		statuses := results[target]
+		for _, cve := range sortedCves(statuses) {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", cve, statuses[cve], target)
+		}
+	}
+	_ = w.Flush()
+}
+
+func sortedTargets(results Results) []string {
+	targets := make([]string, 0, len(results))
+	for target := range results {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+func sortedCves(statuses CveApplicability) []string {
+	cves := make([]string, 0, len(statuses))
+	for cve := range statuses {
+		cves = append(cves, cve)
+	}
+	sort.Strings(cves)
+	return cves
+}
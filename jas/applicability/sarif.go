@@ -0,0 +1,79 @@
+package applicability
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// sarifReport mirrors only the subset of the SARIF 2.1.0 schema the analyzer-manager populates
+// for applicability results: one rule per CVE, and a result per rule that was evaluated.
+type sarifReport struct {
+	Runs []struct {
+		Tool struct {
+			Driver struct {
+				Rules []struct {
+					Id string `json:"id"`
+				} `json:"rules"`
+			} `json:"driver"`
+		} `json:"tool"`
+		Results []struct {
+			RuleId  string `json:"ruleId"`
+			Kind    string `json:"kind"`
+			Message struct {
+				Text string `json:"text"`
+			} `json:"message"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+// parseSarif reads the analyzer-manager's SARIF output and derives an applicability status per
+// requested CVE:
+//   - a "fail" kind result for the CVE's rule means the vulnerable code path is reachable -> Applicable
+//   - a "pass" kind result means the rule ran and found no reachable path -> Not Applicable
+//   - no result for the CVE's rule (e.g. no applicability scanner covers it) -> Undetermined
+func parseSarif(sarifPath string, cves []string) (CveApplicability, error) {
+	statuses := make(CveApplicability, len(cves))
+	for _, cve := range cves {
+		statuses[cve] = Undetermined
+	}
+
+	content, err := os.ReadFile(sarifPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return statuses, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	var report sarifReport
+	if err = json.Unmarshal(content, &report); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+
+	for _, run := range report.Runs {
+		for _, result := range run.Results {
+			cve := cveFromRuleId(result.RuleId)
+			if _, requested := statuses[cve]; !requested {
+				continue
+			}
+			switch strings.ToLower(result.Kind) {
+			case "fail":
+				statuses[cve] = Applicable
+			case "pass":
+				statuses[cve] = NotApplicable
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// cveFromRuleId strips the analyzer-manager's rule id prefix (e.g. "applicability_CVE-2023-1234")
+// down to the bare CVE id.
+func cveFromRuleId(ruleId string) string {
+	if idx := strings.LastIndex(ruleId, "_"); idx != -1 && strings.HasPrefix(ruleId, "applicability_") {
+		return ruleId[idx+1:]
+	}
+	return ruleId
+}
@@ -0,0 +1,176 @@
+package scan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+const checkpointDirName = "scans"
+
+// checkpointEntry records the outcome of scanning a single file, so a later run with --resume can
+// tell whether it's safe to skip that file instead of re-indexing and re-scanning it. Result is
+// the authoritative data reused on resume; ResultSummary is kept alongside purely so the checkpoint
+// file is readable without decoding Result.
+type checkpointEntry struct {
+	Path          string                 `json:"path"`
+	Sha256        string                 `json:"sha256"`
+	ScanId        string                 `json:"scanId,omitempty"`
+	ResultSummary string                 `json:"resultSummary,omitempty"`
+	Result        *services.ScanResponse `json:"result,omitempty"`
+	Completed     bool                   `json:"completed"`
+}
+
+// checkpoint is the on-disk, per-run state that lets a scan resume after a crash or network
+// hiccup without starting over on files it already finished.
+type checkpoint struct {
+	RunId   string                     `json:"runId"`
+	Entries map[string]checkpointEntry `json:"entries"`
+
+	mu   sync.Mutex
+	path string
+}
+
+// checkpointPath returns the path a checkpoint with the given run id is persisted at, creating the
+// containing directory if needed.
+func checkpointPath(runId string) (string, error) {
+	jfrogHomeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(jfrogHomeDir, checkpointDirName)
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.Join(dir, runId+".json"), nil
+}
+
+// loadCheckpoint reads the checkpoint for runId from disk, or returns a fresh, empty one if none
+// exists yet or the file on disk is corrupt (e.g. truncated by a crash mid-write), in which case a
+// warning is logged and the run starts over rather than failing outright.
+func loadCheckpoint(runId string) (*checkpoint, error) {
+	path, err := checkpointPath(runId)
+	if err != nil {
+		return nil, err
+	}
+	chkpt := &checkpoint{RunId: runId, Entries: make(map[string]checkpointEntry), path: path}
+
+	exists, err := fileutils.IsFileExists(path, false)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return chkpt, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if err = json.Unmarshal(content, chkpt); err != nil {
+		log.Warn(fmt.Sprintf("Checkpoint %s is corrupt (%s); starting this run from scratch.", path, err.Error()))
+		chkpt.Entries = make(map[string]checkpointEntry)
+		chkpt.RunId = runId
+		chkpt.path = path
+		return chkpt, nil
+	}
+	if chkpt.Entries == nil {
+		chkpt.Entries = make(map[string]checkpointEntry)
+	}
+	chkpt.path = path
+	return chkpt, nil
+}
+
+// completedResult returns the persisted scan result for path if a previous run of this checkpoint
+// already completed it and its content hasn't changed since, so the caller can fold it back into
+// this run's results instead of silently dropping it.
+func (chkpt *checkpoint) completedResult(path, sha256Sum string) (*services.ScanResponse, bool) {
+	chkpt.mu.Lock()
+	defer chkpt.mu.Unlock()
+	entry, ok := chkpt.Entries[path]
+	if !ok || !entry.Completed || entry.Sha256 != sha256Sum || entry.Result == nil {
+		return nil, false
+	}
+	return entry.Result, true
+}
+
+// markCompleted records that path finished scanning successfully, together with its full result so
+// a later --resume run can reuse it, and persists the checkpoint immediately so a network hiccup
+// partway through a large scan only costs the in-flight files.
+func (chkpt *checkpoint) markCompleted(path, sha256Sum string, result *services.ScanResponse) error {
+	chkpt.mu.Lock()
+	chkpt.Entries[path] = checkpointEntry{
+		Path:          path,
+		Sha256:        sha256Sum,
+		ScanId:        result.ScanId,
+		ResultSummary: resultSummary(result.ScanId, len(result.Vulnerabilities), len(result.Violations)),
+		Result:        result,
+		Completed:     true,
+	}
+	chkpt.mu.Unlock()
+	return chkpt.save()
+}
+
+// save atomically replaces the checkpoint file on disk: it writes to a temp file in the same
+// directory and renames it over the real path, so a crash mid-write can never leave a truncated,
+// unreadable checkpoint behind - the exact failure mode this feature exists to survive.
+func (chkpt *checkpoint) save() error {
+	chkpt.mu.Lock()
+	content, err := json.Marshal(chkpt)
+	chkpt.mu.Unlock()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(chkpt.path), filepath.Base(chkpt.path)+".*.tmp")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	tmpPath := tmpFile.Name()
+	_, writeErr := tmpFile.Write(content)
+	closeErr := tmpFile.Close()
+	if writeErr != nil {
+		_ = os.Remove(tmpPath)
+		return errorutils.CheckError(writeErr)
+	}
+	if closeErr != nil {
+		_ = os.Remove(tmpPath)
+		return errorutils.CheckError(closeErr)
+	}
+	if err = os.Rename(tmpPath, chkpt.path); err != nil {
+		_ = os.Remove(tmpPath)
+		return errorutils.CheckError(err)
+	}
+	return nil
+}
+
+// fileSha256 hashes a file's content so checkpoint entries can detect a file that changed since
+// the previous run and must be re-scanned regardless of --resume.
+func fileSha256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	hasher := sha256.New()
+	if _, err = io.Copy(hasher, file); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func resultSummary(scanId string, vulnerabilities, violations int) string {
+	return fmt.Sprintf("scanId=%s vulnerabilities=%d violations=%d", scanId, vulnerabilities, violations)
+}
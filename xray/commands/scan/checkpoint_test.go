@@ -0,0 +1,72 @@
+package scan
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCheckpoint(t *testing.T) *checkpoint {
+	return &checkpoint{
+		RunId:   "test-run",
+		Entries: make(map[string]checkpointEntry),
+		path:    filepath.Join(t.TempDir(), "test-run.json"),
+	}
+}
+
+func TestCheckpoint_MarkCompletedAndCompletedResult(t *testing.T) {
+	chkpt := newTestCheckpoint(t)
+	result := &services.ScanResponse{ScanId: "scan-1"}
+
+	_, ok := chkpt.completedResult("foo.txt", "sha1")
+	assert.False(t, ok, "nothing marked completed yet")
+
+	require.NoError(t, chkpt.markCompleted("foo.txt", "sha1", result))
+
+	found, ok := chkpt.completedResult("foo.txt", "sha1")
+	require.True(t, ok)
+	assert.Equal(t, "scan-1", found.ScanId)
+
+	_, ok = chkpt.completedResult("foo.txt", "sha-changed")
+	assert.False(t, ok, "content hash changed since it was completed")
+}
+
+func TestCheckpoint_SaveIsAtomicAndReloadable(t *testing.T) {
+	chkpt := newTestCheckpoint(t)
+	require.NoError(t, chkpt.markCompleted("foo.txt", "sha1", &services.ScanResponse{ScanId: "scan-1"}))
+
+	// No .tmp files should be left behind after a successful save.
+	entries, err := os.ReadDir(filepath.Dir(chkpt.path))
+	require.NoError(t, err)
+	for _, entry := range entries {
+		assert.NotContains(t, entry.Name(), ".tmp")
+	}
+
+	content, err := os.ReadFile(chkpt.path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "scan-1")
+
+	var reloaded checkpoint
+	require.NoError(t, json.Unmarshal(content, &reloaded))
+	assert.True(t, reloaded.Entries["foo.txt"].Completed)
+	assert.Equal(t, "scan-1", reloaded.Entries["foo.txt"].ScanId)
+}
+
+func TestFileSha256(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "content.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0644))
+
+	sum, err := fileSha256(path)
+	require.NoError(t, err)
+	// sha256("hello")
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", sum)
+}
+
+func TestResultSummary(t *testing.T) {
+	assert.Equal(t, "scanId=scan-1 vulnerabilities=2 violations=1", resultSummary("scan-1", 2, 1))
+}
@@ -0,0 +1,159 @@
+package scan
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/jas/applicability"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// imageLayer is a single layer extracted from a Docker image, ready to be fed into the existing
+// indexFile pipeline like any other file on disk. digest is the sha256 of the layer tarball's own
+// content (not a path inside the archive), so it's stable across independent "docker save" runs of
+// the same image and can be compared directly for base-vs-added layer attribution.
+type imageLayer struct {
+	digest  string
+	tarPath string
+}
+
+// dockerManifestEntry mirrors the subset of "docker save"'s manifest.json needed to enumerate an
+// image's layers in order. The image config (manifest.Config) isn't a scannable dependency
+// manifest, so it's deliberately left unparsed here rather than fed into the indexer pipeline.
+type dockerManifestEntry struct {
+	Layers []string `json:"Layers"`
+}
+
+// pullImageLayers pulls imageRef via the local Docker daemon, exports it to an OCI/Docker archive
+// and returns the path to each layer's tarball on disk, in base-to-top order. The returned cleanup
+// func removes the temporary directory the image was extracted into.
+func pullImageLayers(imageRef string) ([]imageLayer, func(), error) {
+	if err := runDockerCommand("pull", imageRef); err != nil {
+		return nil, nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "jfrog-docker-scan")
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	cleanup := func() { _ = os.RemoveAll(tmpDir) }
+
+	archivePath := filepath.Join(tmpDir, "image.tar")
+	if err = runDockerCommand("save", "-o", archivePath, imageRef); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	extractDir := filepath.Join(tmpDir, "extracted")
+	if err = extractTarGzToDir(archivePath, extractDir); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+
+	manifestBytes, err := os.ReadFile(filepath.Join(extractDir, "manifest.json"))
+	if err != nil {
+		cleanup()
+		return nil, nil, errorutils.CheckError(err)
+	}
+	var manifests []dockerManifestEntry
+	if err = json.Unmarshal(manifestBytes, &manifests); err != nil {
+		cleanup()
+		return nil, nil, errorutils.CheckError(err)
+	}
+	if len(manifests) == 0 {
+		cleanup()
+		return nil, nil, errorutils.CheckErrorf("docker save produced an empty manifest for %s", imageRef)
+	}
+
+	var layers []imageLayer
+	for _, layerPath := range manifests[0].Layers {
+		tarPath := filepath.Join(extractDir, layerPath)
+		digest, err := layerDigest(tarPath)
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		layers = append(layers, imageLayer{digest: digest, tarPath: tarPath})
+	}
+	return layers, cleanup, nil
+}
+
+// layerDigest hashes a layer tarball's own content, giving each layer a real content digest
+// ("sha256:<hex>") instead of reusing the manifest's tar path - which is just a directory name
+// inside the archive and isn't guaranteed to line up across two independent "docker save" runs of
+// the same image.
+func layerDigest(tarPath string) (string, error) {
+	file, err := os.Open(tarPath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	hasher := sha256.New()
+	if _, err = io.Copy(hasher, file); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return "sha256:" + hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func runDockerCommand(args ...string) error {
+	cmd := exec.Command("docker", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return errorutils.CheckErrorf("docker %v failed: %s\n%s", args, err.Error(), string(output))
+	}
+	return nil
+}
+
+// extractTarGzToDir extracts a plain tar archive (as produced by "docker save", which is not
+// gzip-compressed) into destDir.
+func extractTarGzToDir(archivePath, destDir string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	tarReader := tar.NewReader(file)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		targetPath, err := applicability.SafeJoin(destDir, header.Name)
+		if err != nil {
+			return err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(targetPath, 0755); err != nil {
+				return errorutils.CheckError(err)
+			}
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+				return errorutils.CheckError(err)
+			}
+			outFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode))
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+			_, copyErr := io.Copy(outFile, tarReader)
+			_ = outFile.Close()
+			if copyErr != nil {
+				return errorutils.CheckError(copyErr)
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package scan
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestTar builds a plain (non-gzipped) tar archive at path containing the given entries, the
+// same format "docker save" produces.
+func writeTestTar(t *testing.T, path string, entries map[string]string) {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range entries {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+}
+
+func TestExtractTarGzToDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	archivePath := filepath.Join(tmpDir, "image.tar")
+	writeTestTar(t, archivePath, map[string]string{
+		"manifest.json":    `[{"Config":"config.json","Layers":["layer1/layer.tar"]}]`,
+		"layer1/layer.tar": "fake-layer-content",
+	})
+
+	destDir := filepath.Join(tmpDir, "extracted")
+	require.NoError(t, extractTarGzToDir(archivePath, destDir))
+
+	content, err := os.ReadFile(filepath.Join(destDir, "layer1", "layer.tar"))
+	require.NoError(t, err)
+	assert.Equal(t, "fake-layer-content", string(content))
+}
+
+func TestExtractTarGzToDir_RejectsPathTraversal(t *testing.T) {
+	tmpDir := t.TempDir()
+	archivePath := filepath.Join(tmpDir, "image.tar")
+	writeTestTar(t, archivePath, map[string]string{
+		"../escape.json": "malicious",
+	})
+
+	destDir := filepath.Join(tmpDir, "extracted")
+	err := extractTarGzToDir(archivePath, destDir)
+	assert.Error(t, err)
+}
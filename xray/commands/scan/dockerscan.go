@@ -0,0 +1,212 @@
+package scan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/commands"
+	xrutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// dockerScanResult attributes a single Xray scan response to the image layer it came from, so the
+// consolidated report can point at which layer introduced a vulnerable component.
+type dockerScanResult struct {
+	layerIndex  int
+	layerDigest string
+	response    *services.ScanResponse
+}
+
+// DockerScanCommand scans a Docker image directly by its reference, indexing each of its layers
+// individually and attributing findings back to the layer they came from, instead of requiring
+// the caller to first export the image to a file-system pattern that ScanCommand can walk.
+type DockerScanCommand struct {
+	*ScanCommand
+	imageRef     string
+	baseImageRef string
+}
+
+func NewDockerScanCommand() *DockerScanCommand {
+	return &DockerScanCommand{ScanCommand: NewScanCommand()}
+}
+
+// SetImageRef sets the image reference to scan, e.g. "my-registry/my-repo:1.0" or
+// "my-registry/my-repo@sha256:abcdef...".
+func (dockerScanCmd *DockerScanCommand) SetImageRef(imageRef string) *DockerScanCommand {
+	dockerScanCmd.imageRef = imageRef
+	return dockerScanCmd
+}
+
+// SetBaseImageRef sets the reference of the base image imageRef was built FROM, so layer
+// attribution can tell exactly which layers came from the base image instead of guessing that only
+// the last layer was added.
+func (dockerScanCmd *DockerScanCommand) SetBaseImageRef(baseImageRef string) *DockerScanCommand {
+	dockerScanCmd.baseImageRef = baseImageRef
+	return dockerScanCmd
+}
+
+func (dockerScanCmd *DockerScanCommand) SetServerDetails(server *config.ServerDetails) *DockerScanCommand {
+	dockerScanCmd.serverDetails = server
+	return dockerScanCmd
+}
+
+func (dockerScanCmd *DockerScanCommand) CommandName() string {
+	return "xr_docker_scan"
+}
+
+func (dockerScanCmd *DockerScanCommand) Run() (err error) {
+	xrayManager, xrayVersion, err := commands.CreateXrayServiceManagerAndGetVersion(dockerScanCmd.serverDetails)
+	if err != nil {
+		return err
+	}
+	if err = commands.ValidateXrayMinimumVersion(xrayVersion, commands.GraphScanMinXrayVersion); err != nil {
+		return err
+	}
+	dockerScanCmd.indexerPath, err = xrutils.DownloadIndexerIfNeeded(xrayManager, xrayVersion)
+	if err != nil {
+		return err
+	}
+
+	layers, cleanup, err := pullImageLayers(dockerScanCmd.imageRef)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	log.Info(fmt.Sprintf("Pulled %s, scanning %d layers.", dockerScanCmd.imageRef, len(layers)))
+
+	repoPath := getXrayRepoPathFromDockerRef(dockerScanCmd.imageRef)
+	resultsArr := make([]*dockerScanResult, len(layers))
+	producer := parallel.NewRunner(dockerScanCmd.threads, len(layers), false)
+	errorsQueue := clientutils.NewErrorsQueue(1)
+
+	go func() {
+		defer producer.Done()
+		for i, layer := range layers {
+			dockerScanCmd.addLayerScanTask(producer, errorsQueue, resultsArr, repoPath, xrayVersion, i, layer)
+		}
+	}()
+	producer.Run()
+
+	flatResults, layerAttribution := dockerScanCmd.flattenDockerResults(resultsArr)
+	if err = dockerScanCmd.handleResults(flatResults, xrayVersion); err != nil {
+		return err
+	}
+	printLayerAttribution(layerAttribution, dockerScanCmd.baseLayerCount(layers))
+	return errorsQueue.GetError()
+}
+
+// baseLayerCount returns how many of layers (from the bottom up) came from the base image, so
+// printLayerAttribution can tell base-image layers apart from ones the caller's own Dockerfile
+// added. When no base image ref was set, it falls back to treating everything but the last layer
+// as the base image, and warns that the result may be inaccurate for multi-instruction Dockerfiles.
+func (dockerScanCmd *DockerScanCommand) baseLayerCount(layers []imageLayer) int {
+	if dockerScanCmd.baseImageRef == "" {
+		log.Warn("No base image ref set (--base-image-ref); layer attribution will assume only the " +
+			"last layer was added on top of the base image, which may be inaccurate.")
+		if len(layers) == 0 {
+			return 0
+		}
+		return len(layers) - 1
+	}
+
+	baseLayers, cleanup, err := pullImageLayers(dockerScanCmd.baseImageRef)
+	if err != nil {
+		log.Warn(fmt.Sprintf("Failed pulling base image %s for layer attribution: %s", dockerScanCmd.baseImageRef, err.Error()))
+		return 0
+	}
+	defer cleanup()
+
+	count := 0
+	for count < len(layers) && count < len(baseLayers) && layers[count].digest == baseLayers[count].digest {
+		count++
+	}
+	return count
+}
+
+func (dockerScanCmd *DockerScanCommand) addLayerScanTask(producer parallel.Runner, errorsQueue *clientutils.ErrorsQueue, resultsArr []*dockerScanResult, repoPath, xrayVersion string, layerIndex int, layer imageLayer) {
+	taskFunc := func(threadId int) (err error) {
+		logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, false)
+		log.Info(logMsgPrefix+"Indexing layer:", layer.digest)
+		graph, err := dockerScanCmd.indexFile(layer.tarPath)
+		if err != nil {
+			return err
+		}
+		if graph.Id == "" {
+			return nil
+		}
+		params := services.XrayGraphScanParams{
+			Graph:      graph,
+			RepoPath:   repoPath,
+			Watches:    dockerScanCmd.watches,
+			ProjectKey: dockerScanCmd.projectKey,
+			ScanType:   services.Binary,
+		}
+		scanResults, err := commands.RunScanGraphAndGetResults(dockerScanCmd.serverDetails, params, dockerScanCmd.includeVulnerabilities, dockerScanCmd.includeLicenses, xrayVersion)
+		if err != nil {
+			log.Error(fmt.Sprintf("Scanning layer %s failed with error: %s", layer.digest, err.Error()))
+			return err
+		}
+		dockerScanCmd.targetPaths.Store(scanResults.ScanId, layer.tarPath)
+		resultsArr[layerIndex] = &dockerScanResult{layerIndex: layerIndex, layerDigest: layer.digest, response: scanResults}
+		return nil
+	}
+	producer.AddTaskWithError(taskFunc, errorsQueue.AddError)
+}
+
+func (dockerScanCmd *DockerScanCommand) flattenDockerResults(resultsArr []*dockerScanResult) ([]services.ScanResponse, []*dockerScanResult) {
+	var flatResults []services.ScanResponse
+	var layerAttribution []*dockerScanResult
+	for _, res := range resultsArr {
+		if res == nil {
+			continue
+		}
+		flatResults = append(flatResults, *res.response)
+		layerAttribution = append(layerAttribution, res)
+	}
+	return flatResults, layerAttribution
+}
+
+// printLayerAttribution prints which layer introduced each vulnerable component, as a supplement
+// to the standard vulnerability table PrintScanResults already printed. baseLayerCount is the
+// number of layers, from the bottom up, that came from the base image rather than being added by
+// the caller's own Dockerfile.
+func printLayerAttribution(results []*dockerScanResult, baseLayerCount int) {
+	if len(results) == 0 {
+		return
+	}
+	log.Info("Layer attribution:")
+	for _, res := range results {
+		origin := "base image"
+		if res.layerIndex >= baseLayerCount {
+			origin = "added layer"
+		}
+		componentCount := len(res.response.Vulnerabilities) + len(res.response.Violations)
+		log.Info(fmt.Sprintf("  layer %d (%s, %s): %d findings", res.layerIndex, res.layerDigest, origin, componentCount))
+		for _, vuln := range res.response.Vulnerabilities {
+			for componentId := range vuln.Components {
+				log.Info(fmt.Sprintf("    %s", componentId))
+			}
+		}
+		for _, violation := range res.response.Violations {
+			for componentId := range violation.Components {
+				log.Info(fmt.Sprintf("    %s", componentId))
+			}
+		}
+	}
+}
+
+// getXrayRepoPathFromDockerRef builds the repo-path Xray expects out of the registry+repo portion
+// of a Docker image reference, stripping the tag or digest.
+func getXrayRepoPathFromDockerRef(imageRef string) string {
+	ref := imageRef
+	if idx := strings.LastIndex(ref, "@"); idx != -1 {
+		ref = ref[:idx]
+	} else if idx := strings.LastIndex(ref, ":"); idx != -1 && !strings.Contains(ref[idx:], "/") {
+		ref = ref[:idx]
+	}
+	return ref + "/"
+}
@@ -0,0 +1,33 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseLayerCount_NoBaseImageRefSet(t *testing.T) {
+	dockerScanCmd := NewDockerScanCommand()
+
+	assert.Equal(t, 0, dockerScanCmd.baseLayerCount(nil))
+	assert.Equal(t, 2, dockerScanCmd.baseLayerCount([]imageLayer{{digest: "a"}, {digest: "b"}, {digest: "c"}}))
+}
+
+func TestGetXrayRepoPathFromDockerRef(t *testing.T) {
+	tests := []struct {
+		name     string
+		imageRef string
+		expected string
+	}{
+		{"tagged", "my-registry/my-repo:1.0", "my-registry/my-repo/"},
+		{"digest", "my-registry/my-repo@sha256:abcdef", "my-registry/my-repo/"},
+		{"no tag or digest", "my-registry/my-repo", "my-registry/my-repo/"},
+		{"port in registry host, no tag", "localhost:5000/my-repo", "localhost:5000/my-repo/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getXrayRepoPathFromDockerRef(tt.imageRef))
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package scan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// ProgressReporter is notified as a scan progresses through its two stages - indexing files on
+// disk and sending the indexed graphs to Xray - so a long-running scan of thousands of artifacts
+// gives the user continuous feedback instead of going silent until it completes.
+type ProgressReporter interface {
+	// Start is called once, before any file is processed.
+	Start()
+	// UpdateFile is called when a file has been indexed.
+	UpdateFile(path string)
+	// FinishFile is called when a file's graph scan has completed.
+	FinishFile(path string)
+	// Error is called when either indexing or scanning a file failed.
+	Error(path string, err error)
+}
+
+// terminalProgressReporter renders a two-line, in-place progress display: one line for files
+// indexed, one for graph scans completed against Xray.
+type terminalProgressReporter struct {
+	writer  io.Writer
+	mu      sync.Mutex
+	indexed int
+	scanned int
+	errored int
+	started bool
+}
+
+// NewTerminalProgressReporter returns the default ProgressReporter, which renders a two-line
+// progress bar to stderr so it doesn't interleave with the scan results printed to stdout.
+func NewTerminalProgressReporter() ProgressReporter {
+	return &terminalProgressReporter{writer: os.Stderr}
+}
+
+func (reporter *terminalProgressReporter) Start() {
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	reporter.started = true
+	reporter.render()
+}
+
+func (reporter *terminalProgressReporter) UpdateFile(path string) {
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	reporter.indexed++
+	reporter.render()
+}
+
+func (reporter *terminalProgressReporter) FinishFile(path string) {
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	reporter.scanned++
+	reporter.render()
+}
+
+func (reporter *terminalProgressReporter) Error(path string, err error) {
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	reporter.errored++
+	reporter.render()
+}
+
+// render reprints both progress lines in place. The caller must hold reporter.mu.
+func (reporter *terminalProgressReporter) render() {
+	if !reporter.started {
+		return
+	}
+	// Clear and redraw both lines, then move the cursor back up one line to the start of the first,
+	// so the next render overwrites them in place.
+	fmt.Fprintf(reporter.writer, "\033[2K\rFiles indexed:        %d\n", reporter.indexed)
+	fmt.Fprintf(reporter.writer, "\033[2K\rGraph scans completed: %d (errors: %d)\033[1A\r", reporter.scanned, reporter.errored)
+}
+
+// progressEvent is a single JSON-lines record emitted by jsonProgressReporter.
+type progressEvent struct {
+	Event string `json:"event"`
+	Path  string `json:"path,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// jsonProgressReporter emits one JSON object per line for each progress event, so CI systems can
+// consume scan progress as a structured log stream instead of parsing a terminal-oriented display.
+type jsonProgressReporter struct {
+	encoder *json.Encoder
+	mu      sync.Mutex
+}
+
+// NewJSONProgressReporter returns a ProgressReporter suited for CI: each event is written to w as
+// its own JSON line.
+func NewJSONProgressReporter(w io.Writer) ProgressReporter {
+	return &jsonProgressReporter{encoder: json.NewEncoder(w)}
+}
+
+func (reporter *jsonProgressReporter) emit(event progressEvent) {
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	// Encoding errors here would only mean the output stream is broken, in which case there's
+	// nowhere left to report the failure to.
+	_ = reporter.encoder.Encode(event)
+}
+
+func (reporter *jsonProgressReporter) Start() {
+	reporter.emit(progressEvent{Event: "start"})
+}
+
+func (reporter *jsonProgressReporter) UpdateFile(path string) {
+	reporter.emit(progressEvent{Event: "indexed", Path: path})
+}
+
+func (reporter *jsonProgressReporter) FinishFile(path string) {
+	reporter.emit(progressEvent{Event: "finished", Path: path})
+}
+
+func (reporter *jsonProgressReporter) Error(path string, err error) {
+	reporter.emit(progressEvent{Event: "error", Path: path, Error: err.Error()})
+}
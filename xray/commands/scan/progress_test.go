@@ -0,0 +1,36 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONProgressReporter_EmitsOneEventPerLine(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := NewJSONProgressReporter(&buf)
+
+	reporter.Start()
+	reporter.UpdateFile("foo.txt")
+	reporter.FinishFile("foo.txt")
+	reporter.Error("bar.txt", errors.New("boom"))
+
+	var events []progressEvent
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var event progressEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.Len(t, events, 4)
+
+	assert.Equal(t, progressEvent{Event: "start"}, events[0])
+	assert.Equal(t, progressEvent{Event: "indexed", Path: "foo.txt"}, events[1])
+	assert.Equal(t, progressEvent{Event: "finished", Path: "foo.txt"}, events[2])
+	assert.Equal(t, progressEvent{Event: "error", Path: "bar.txt", Error: "boom"}, events[3])
+}
@@ -0,0 +1,353 @@
+package scan
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/xray/commands"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+// SBOMFormat represents the format of a pre-built SBOM file that can be fed into ScanCommand
+// instead of a filesystem pattern that gets indexed by the Xray indexer.
+type SBOMFormat string
+
+const (
+	CycloneDxJSON SBOMFormat = "cyclonedx-json"
+	CycloneDxXML  SBOMFormat = "cyclonedx-xml"
+	SpdxJSON      SBOMFormat = "spdx-json"
+	SpdxTagValue  SBOMFormat = "spdx-tag-value"
+)
+
+// runSBOMScan parses the SBOM at scanCmd.sbomPath into a dependency graph and sends it to Xray
+// for scanning, the same way an indexed file would be.
+func (scanCmd *ScanCommand) runSBOMScan(xrayVersion string) ([]services.ScanResponse, error) {
+	if scanCmd.sbomRepoPath == "" {
+		return nil, errorutils.CheckErrorf("SBOM scan requires a target repo path; call SetSBOMRepoPath before Run")
+	}
+	format := scanCmd.sbomFormat
+	if format == "" {
+		detected, err := detectSBOMFormat(scanCmd.sbomPath)
+		if err != nil {
+			return nil, err
+		}
+		format = detected
+	}
+	graph, err := parseSBOM(scanCmd.sbomPath, format)
+	if err != nil {
+		return nil, err
+	}
+	params := services.XrayGraphScanParams{
+		Graph:      graph,
+		RepoPath:   scanCmd.sbomRepoPath,
+		Watches:    scanCmd.watches,
+		ProjectKey: scanCmd.projectKey,
+		ScanType:   services.Binary,
+	}
+	scanResults, err := commands.RunScanGraphAndGetResults(scanCmd.serverDetails, params, scanCmd.includeVulnerabilities, scanCmd.includeLicenses, xrayVersion)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("scanning SBOM %s failed with error: %s", scanCmd.sbomPath, err.Error())
+	}
+	return []services.ScanResponse{*scanResults}, nil
+}
+
+// detectSBOMFormat sniffs the content of an SBOM file to determine whether it's CycloneDX or SPDX,
+// and whether it's encoded as JSON or XML/tag-value.
+func detectSBOMFormat(path string) (SBOMFormat, error) {
+	content, err := fileutils.ReadFile(path)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	trimmed := strings.TrimSpace(string(content))
+	switch {
+	case strings.HasPrefix(trimmed, "<"):
+		return CycloneDxXML, nil
+	case strings.HasPrefix(trimmed, "{"):
+		if strings.Contains(trimmed, "\"spdxVersion\"") {
+			return SpdxJSON, nil
+		}
+		return CycloneDxJSON, nil
+	case strings.HasPrefix(trimmed, "SPDXVersion:"):
+		return SpdxTagValue, nil
+	default:
+		return "", errorutils.CheckErrorf("could not auto-detect the SBOM format of %s", path)
+	}
+}
+
+// parseSBOM reads the SBOM file at path in the given format and builds the services.GraphNode
+// tree that the rest of the scan pipeline expects, exactly as the Xray indexer would produce it.
+func parseSBOM(path string, format SBOMFormat) (*services.GraphNode, error) {
+	content, err := fileutils.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	switch format {
+	case CycloneDxJSON:
+		return parseCycloneDxJSON(content)
+	case CycloneDxXML:
+		return parseCycloneDxXML(content)
+	case SpdxJSON:
+		return parseSpdxJSON(content)
+	case SpdxTagValue:
+		return parseSpdxTagValue(content)
+	default:
+		return nil, errorutils.CheckErrorf("unsupported SBOM format: %s", format)
+	}
+}
+
+type cyclonedxComponent struct {
+	BomRef  string `json:"bom-ref"`
+	Purl    string `json:"purl"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type cyclonedxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+type cyclonedxBOM struct {
+	Components   []cyclonedxComponent  `json:"components"`
+	Dependencies []cyclonedxDependency `json:"dependencies"`
+	Metadata     struct {
+		Component cyclonedxComponent `json:"component"`
+	} `json:"metadata"`
+}
+
+func parseCycloneDxJSON(content []byte) (*services.GraphNode, error) {
+	var bom cyclonedxBOM
+	if err := json.Unmarshal(content, &bom); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return buildGraphFromCycloneDX(bom)
+}
+
+// cyclonedxXML mirrors only the subset of the CycloneDX XML schema needed to rebuild the
+// dependency graph: components and their declared dependency relationships.
+type cyclonedxXML struct {
+	Components struct {
+		Component []struct {
+			BomRef  string `xml:"bom-ref,attr"`
+			Purl    string `xml:"purl"`
+			Name    string `xml:"name"`
+			Version string `xml:"version"`
+		} `xml:"component"`
+	} `xml:"components"`
+	Dependencies struct {
+		Dependency []struct {
+			Ref       string `xml:"ref,attr"`
+			DependsOn []struct {
+				Ref string `xml:"ref,attr"`
+			} `xml:"dependency"`
+		} `xml:"dependency"`
+	} `xml:"dependencies"`
+}
+
+func parseCycloneDxXML(content []byte) (*services.GraphNode, error) {
+	var doc cyclonedxXML
+	if err := xml.Unmarshal(content, &doc); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var bom cyclonedxBOM
+	for _, c := range doc.Components.Component {
+		bom.Components = append(bom.Components, cyclonedxComponent{BomRef: c.BomRef, Purl: c.Purl, Name: c.Name, Version: c.Version})
+	}
+	for _, d := range doc.Dependencies.Dependency {
+		dep := cyclonedxDependency{Ref: d.Ref}
+		for _, on := range d.DependsOn {
+			dep.DependsOn = append(dep.DependsOn, on.Ref)
+		}
+		bom.Dependencies = append(bom.Dependencies, dep)
+	}
+	return buildGraphFromCycloneDX(bom)
+}
+
+func buildGraphFromCycloneDX(bom cyclonedxBOM) (*services.GraphNode, error) {
+	nodesByRef := make(map[string]*services.GraphNode)
+	for _, c := range bom.Components {
+		componentId, err := purlToComponentId(c.Purl)
+		if err != nil {
+			// Components without a resolvable PURL can't be matched against Xray's vulnerability
+			// database, so they're skipped rather than failing the whole scan.
+			continue
+		}
+		nodesByRef[c.BomRef] = &services.GraphNode{Id: componentId}
+	}
+	for _, dep := range bom.Dependencies {
+		parent, ok := nodesByRef[dep.Ref]
+		if !ok {
+			continue
+		}
+		for _, childRef := range dep.DependsOn {
+			if child, ok := nodesByRef[childRef]; ok {
+				parent.Nodes = append(parent.Nodes, child)
+			}
+		}
+	}
+	root := &services.GraphNode{Id: sbomComponentId(bom.Metadata.Component)}
+	for ref, node := range nodesByRef {
+		if !isReferencedAsChild(ref, bom.Dependencies) {
+			root.Nodes = append(root.Nodes, node)
+		}
+	}
+	return root, nil
+}
+
+func sbomComponentId(root cyclonedxComponent) string {
+	if id, err := purlToComponentId(root.Purl); err == nil {
+		return id
+	}
+	return fmt.Sprintf("generic://%s:%s", root.Name, root.Version)
+}
+
+func isReferencedAsChild(ref string, dependencies []cyclonedxDependency) bool {
+	for _, dep := range dependencies {
+		for _, childRef := range dep.DependsOn {
+			if childRef == ref {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type spdxPackage struct {
+	SPDXID       string `json:"SPDXID"`
+	Name         string `json:"name"`
+	VersionInfo  string `json:"versionInfo"`
+	ExternalRefs []struct {
+		ReferenceCategory string `json:"referenceCategory"`
+		ReferenceType     string `json:"referenceType"`
+		ReferenceLocator  string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+}
+
+type spdxRelationship struct {
+	SpdxElementId      string `json:"spdxElementId"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+	RelationshipType   string `json:"relationshipType"`
+}
+
+type spdxDocument struct {
+	SPDXID        string             `json:"SPDXID"`
+	Packages      []spdxPackage      `json:"packages"`
+	Relationships []spdxRelationship `json:"relationships"`
+}
+
+func parseSpdxJSON(content []byte) (*services.GraphNode, error) {
+	var doc spdxDocument
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return buildGraphFromSpdx(doc)
+}
+
+// parseSpdxTagValue is not supported yet, since the tag-value format requires a dedicated line
+// parser rather than a structured unmarshal. Treated as an explicit error until implemented.
+func parseSpdxTagValue(content []byte) (*services.GraphNode, error) {
+	return nil, errorutils.CheckErrorf("SPDX tag-value SBOMs are not supported yet, please provide an SPDX JSON or CycloneDX file instead")
+}
+
+func buildGraphFromSpdx(doc spdxDocument) (*services.GraphNode, error) {
+	nodesById := make(map[string]*services.GraphNode)
+	for _, pkg := range doc.Packages {
+		componentId := ""
+		for _, ref := range pkg.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				if id, err := purlToComponentId(ref.ReferenceLocator); err == nil {
+					componentId = id
+					break
+				}
+			}
+		}
+		if componentId == "" {
+			continue
+		}
+		nodesById[pkg.SPDXID] = &services.GraphNode{Id: componentId}
+	}
+	root := &services.GraphNode{Id: doc.SPDXID}
+	hasParent := make(map[string]bool)
+	for _, rel := range doc.Relationships {
+		if rel.RelationshipType != "DEPENDS_ON" {
+			continue
+		}
+		parent, parentOk := nodesById[rel.SpdxElementId]
+		child, childOk := nodesById[rel.RelatedSpdxElement]
+		if !parentOk || !childOk {
+			continue
+		}
+		parent.Nodes = append(parent.Nodes, child)
+		hasParent[rel.RelatedSpdxElement] = true
+	}
+	for id, node := range nodesById {
+		if !hasParent[id] {
+			root.Nodes = append(root.Nodes, node)
+		}
+	}
+	return root, nil
+}
+
+// purlToComponentId converts a package-url (https://github.com/package-url/purl-spec) into the
+// component id format Xray uses internally, e.g. "pkg:npm/foo@1.2.3" -> "npm://foo:1.2.3" and
+// "pkg:maven/g/a@v" -> "gav://g:a:v".
+func purlToComponentId(purl string) (string, error) {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return "", errorutils.CheckErrorf("not a valid purl: %s", purl)
+	}
+	body := strings.TrimPrefix(purl, "pkg:")
+	// Strip qualifiers/subpath, they don't participate in the Xray component id.
+	if idx := strings.IndexAny(body, "?#"); idx != -1 {
+		body = body[:idx]
+	}
+	slashIdx := strings.Index(body, "/")
+	if slashIdx == -1 {
+		return "", errorutils.CheckErrorf("not a valid purl: %s", purl)
+	}
+	pkgType := body[:slashIdx]
+	rest := body[slashIdx+1:]
+	atIdx := strings.LastIndex(rest, "@")
+	if atIdx == -1 {
+		return "", errorutils.CheckErrorf("purl %s is missing a version", purl)
+	}
+	namePart, err := url.PathUnescape(rest[:atIdx])
+	if err != nil {
+		return "", errorutils.CheckErrorf("invalid percent-encoding in purl name %s: %s", purl, err.Error())
+	}
+	version, err := url.PathUnescape(rest[atIdx+1:])
+	if err != nil {
+		return "", errorutils.CheckErrorf("invalid percent-encoding in purl version %s: %s", purl, err.Error())
+	}
+
+	switch pkgType {
+	case "maven":
+		groupAndArtifact := strings.SplitN(namePart, "/", 2)
+		if len(groupAndArtifact) != 2 {
+			return "", errorutils.CheckErrorf("not a valid maven purl: %s", purl)
+		}
+		return fmt.Sprintf("gav://%s:%s:%s", groupAndArtifact[0], groupAndArtifact[1], version), nil
+	case "pypi":
+		return fmt.Sprintf("pypi://%s:%s", namePart, version), nil
+	case "nuget":
+		return fmt.Sprintf("nuget://%s:%s", namePart, version), nil
+	case "gem":
+		return fmt.Sprintf("rubygems://%s:%s", namePart, version), nil
+	case "golang":
+		return fmt.Sprintf("go://%s:%s", namePart, version), nil
+	case "npm":
+		return fmt.Sprintf("npm://%s:%s", namePart, version), nil
+	case "cargo":
+		return fmt.Sprintf("cargo://%s:%s", namePart, version), nil
+	case "deb":
+		return fmt.Sprintf("deb://%s:%s", namePart, version), nil
+	case "rpm":
+		return fmt.Sprintf("rpm://%s:%s", namePart, version), nil
+	default:
+		return fmt.Sprintf("generic://%s:%s", namePart, version), nil
+	}
+}
@@ -0,0 +1,62 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurlToComponentId(t *testing.T) {
+	tests := []struct {
+		name     string
+		purl     string
+		expected string
+	}{
+		{"maven", "pkg:maven/org.example/libfoo@1.2.3", "gav://org.example:libfoo:1.2.3"},
+		{"pypi", "pkg:pypi/django@4.2.0", "pypi://django:4.2.0"},
+		{"nuget", "pkg:nuget/Newtonsoft.Json@13.0.1", "nuget://Newtonsoft.Json:13.0.1"},
+		{"gem", "pkg:gem/rails@7.0.0", "rubygems://rails:7.0.0"},
+		{"golang", "pkg:golang/github.com/pkg/errors@0.9.1", "go://github.com/pkg/errors:0.9.1"},
+		{"npm", "pkg:npm/lodash@4.17.21", "npm://lodash:4.17.21"},
+		{"npm scoped, percent-encoded namespace", "pkg:npm/%40angular/core@12.0.0", "npm://@angular/core:12.0.0"},
+		{"cargo", "pkg:cargo/serde@1.0.0", "cargo://serde:1.0.0"},
+		{"deb", "pkg:deb/curl@7.81.0", "deb://curl:7.81.0"},
+		{"rpm", "pkg:rpm/curl@7.81.0", "rpm://curl:7.81.0"},
+		{"unknown type falls back to generic", "pkg:conan/zlib@1.2.13", "generic://zlib:1.2.13"},
+		{"qualifiers and subpath are stripped", "pkg:npm/lodash@4.17.21?os=linux#lib/index.js", "npm://lodash:4.17.21"},
+		{"percent-encoded version", "pkg:npm/foo@1.0.0%2Bbuild.1", "npm://foo:1.0.0+build.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			componentId, err := purlToComponentId(tt.purl)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, componentId)
+		})
+	}
+}
+
+func TestRunSBOMScan_RequiresRepoPath(t *testing.T) {
+	scanCmd := NewScanCommand().SetSBOMInput("testdata/doesnotmatter.json", CycloneDxJSON)
+	_, err := scanCmd.runSBOMScan("1.0.0")
+	assert.Error(t, err)
+}
+
+func TestPurlToComponentId_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		purl string
+	}{
+		{"not a purl", "lodash@4.17.21"},
+		{"missing type separator", "pkg:npm"},
+		{"missing version", "pkg:npm/lodash"},
+		{"maven missing artifact", "pkg:maven/org.example@1.2.3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := purlToComponentId(tt.purl)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -7,10 +7,13 @@ import (
 	"github.com/jfrog/gofrog/io"
 	"github.com/jfrog/gofrog/parallel"
 	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	"github.com/jfrog/jfrog-cli-core/v2/jas/applicability"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 	"github.com/jfrog/jfrog-cli-core/v2/xray/commands"
 	xrutils "github.com/jfrog/jfrog-cli-core/v2/xray/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/utils/exportformat"
+	"github.com/jfrog/jfrog-cli-core/v2/xray/utils/tokenvalidation"
 	"github.com/jfrog/jfrog-client-go/artifactory/services/fspatterns"
 	clientutils "github.com/jfrog/jfrog-client-go/utils"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
@@ -20,6 +23,8 @@ import (
 	"os/exec"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 )
 
 type FileContext func(string) parallel.TaskFunc
@@ -28,6 +33,13 @@ type indexFileHandlerFunc func(file string)
 const (
 	indexingCommand          = "graph"
 	fileNotSupportedExitCode = 3
+
+	// CycloneDxVexOutputFormat renders results as a CycloneDX VEX document instead of the default
+	// table/JSON, for consumption by tools like Dependency-Track.
+	CycloneDxVexOutputFormat xrutils.OutputFormat = "cyclonedx-vex"
+	// SarifOutputFormat renders results as a SARIF log instead of the default table/JSON, for
+	// consumption by code-scanning tools like GitHub's.
+	SarifOutputFormat xrutils.OutputFormat = "sarif"
 )
 
 type ScanCommand struct {
@@ -43,6 +55,32 @@ type ScanCommand struct {
 	includeLicenses        bool
 	scanPassed             bool
 	fail                   bool
+	// The path to a pre-built SBOM file. When set, the SBOM is parsed into a dependency graph
+	// instead of invoking the Xray indexer.
+	sbomPath string
+	// The format of the file at sbomPath. Auto-detected in Run() when left empty.
+	sbomFormat SBOMFormat
+	// The Artifactory repo-path to scan the SBOM's dependency graph against, for watch/policy
+	// resolution. Required when sbomPath is set - there's no local file target to derive it from.
+	sbomRepoPath string
+	// Whether to run the contextual applicability analysis (JAS) phase after the Xray scan. Defaults
+	// to true: on an entitled, new-enough Xray server this downloads the analyzer-manager on first
+	// run and unpacks/exec's it against every scanned artifact, which is real added latency. It's
+	// safe to default on because applicability.Scan checks JAS entitlement before doing any of that
+	// and no-ops for unentitled or too-old servers - but entitled users pay the cost on every scan
+	// unless they opt out with --scan-jas=false.
+	scanJas bool
+	// Whether to follow up on any secret/token findings with a live validation call to the issuing service.
+	validateSecrets bool
+	// Maps an Xray scan id to the local path of the artifact that produced it, so the JAS phase
+	// knows what to unpack for applicability analysis.
+	targetPaths sync.Map
+	// Reports indexing/scanning progress as the scan runs. Defaults to a terminal progress bar.
+	progress ProgressReporter
+	// The run id used to persist and resume scan checkpoints. Auto-generated if left empty.
+	runId string
+	// The loaded checkpoint for runId, used to skip files a previous run already completed.
+	checkpoint *checkpoint
 }
 
 func (scanCmd *ScanCommand) SetThreads(threads int) *ScanCommand {
@@ -85,6 +123,14 @@ func (scanCmd *ScanCommand) SetIncludeLicenses(include bool) *ScanCommand {
 	return scanCmd
 }
 
+// SetValidateSecrets enables a live, low-privilege validation call against the issuing service for
+// every secret/token the indexer finds, so results can be annotated with whether the leak is still
+// exploitable.
+func (scanCmd *ScanCommand) SetValidateSecrets(validate bool) *ScanCommand {
+	scanCmd.validateSecrets = validate
+	return scanCmd
+}
+
 func (scanCmd *ScanCommand) ServerDetails() (*config.ServerDetails, error) {
 	return scanCmd.serverDetails, nil
 }
@@ -94,6 +140,48 @@ func (scanCmd *ScanCommand) SetFail(fail bool) *ScanCommand {
 	return scanCmd
 }
 
+// SetSBOMInput configures the command to build its dependency graph from a pre-built SBOM file
+// instead of running it against the Xray indexer. If format is left empty, it is auto-detected
+// from the file content in Run(). The SBOM's own target repo path must be set separately via
+// SetSBOMRepoPath, since a local SBOM file has no Artifactory repo location to derive one from.
+func (scanCmd *ScanCommand) SetSBOMInput(path string, format SBOMFormat) *ScanCommand {
+	scanCmd.sbomPath = path
+	scanCmd.sbomFormat = format
+	return scanCmd
+}
+
+// SetSBOMRepoPath sets the Artifactory repo-path to scan the SBOM's dependency graph against, so
+// Xray can resolve the right watches/policies for it. Required whenever SetSBOMInput is used.
+func (scanCmd *ScanCommand) SetSBOMRepoPath(repoPath string) *ScanCommand {
+	scanCmd.sbomRepoPath = repoPath
+	return scanCmd
+}
+
+// SetScanJas toggles the post-scan contextual applicability analysis phase. It is enabled by
+// default - for an entitled, new-enough Xray server this means every `jf scan` downloads the
+// analyzer-manager on first run and unpacks/exec's it against each scanned artifact. Pass false
+// (e.g. from a --scan-jas=false flag, which the CLI command help should call out alongside this
+// cost) to skip it and rely solely on Xray's own results.
+func (scanCmd *ScanCommand) SetScanJas(scanJas bool) *ScanCommand {
+	scanCmd.scanJas = scanJas
+	return scanCmd
+}
+
+// SetProgressReporter overrides the default terminal progress bar, e.g. with NewJSONProgressReporter
+// for CI environments that want structured progress output instead.
+func (scanCmd *ScanCommand) SetProgressReporter(progress ProgressReporter) *ScanCommand {
+	scanCmd.progress = progress
+	return scanCmd
+}
+
+// SetResume resumes a previous scan from the checkpoint saved under the given run id, skipping any
+// file that checkpoint already recorded as completed. Pass the run id a prior scan logged at
+// startup.
+func (scanCmd *ScanCommand) SetResume(runId string) *ScanCommand {
+	scanCmd.runId = runId
+	return scanCmd
+}
+
 func (scanCmd *ScanCommand) IsScanPassed() bool {
 	return scanCmd.scanPassed
 }
@@ -133,39 +221,73 @@ func (scanCmd *ScanCommand) Run() (err error) {
 	if err != nil {
 		return err
 	}
+	// If a pre-built SBOM was provided, skip the indexer entirely and scan the graph parsed from it.
+	if scanCmd.sbomPath != "" {
+		flatResults, sbomErr := scanCmd.runSBOMScan(xrayVersion)
+		if sbomErr != nil {
+			return sbomErr
+		}
+		if err = scanCmd.handleResults(flatResults, xrayVersion); err != nil {
+			return err
+		}
+		// If includeVulnerabilities is false it means that context was provided, so we need to check for build violations.
+		// If user provided --fail=false, don't fail the build.
+		if scanCmd.fail && scanCmd.includeVulnerabilities == false {
+			if xrutils.CheckIfFailBuild(flatResults) {
+				return xrutils.NewFailBuildError()
+			}
+		}
+		log.Info("Scan completed successfully.")
+		return nil
+	}
+
 	// First download Xray Indexer if needed
 	scanCmd.indexerPath, err = xrutils.DownloadIndexerIfNeeded(xrayManager, xrayVersion)
 	if err != nil {
 		return err
 	}
+	if scanCmd.runId == "" {
+		scanCmd.runId = fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	scanCmd.checkpoint, err = loadCheckpoint(scanCmd.runId)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Scan run id: %s. Resume this scan with --resume %s.", scanCmd.runId, scanCmd.runId))
+	scanCmd.progress.Start()
 	threads := 1
 	if scanCmd.threads > 1 {
 		threads = scanCmd.threads
 	}
 	resultsArr := make([][]*services.ScanResponse, threads)
+	// cachedResultsArr holds --resume cache hits, written by the file-producer pool's own
+	// per-thread slot. It's kept separate from resultsArr (written by the indexed-file-producer
+	// pool) because the two pools number their workers independently, so the same threadId can be
+	// in use by both at once - writing resume hits into resultsArr would race with the indexer pool
+	// appending to the same slice.
+	cachedResultsArr := make([][]*services.ScanResponse, threads)
 	fileProducerConsumer := parallel.NewRunner(scanCmd.threads, 20000, false)
 	fileProducerErrorsQueue := clientutils.NewErrorsQueue(1)
 	indexedFileProducerConsumer := parallel.NewRunner(scanCmd.threads, 20000, false)
 	indexedFileProducerErrorsQueue := clientutils.NewErrorsQueue(1)
 	// Start walking on the filesystem to "produce" files that match the given pattern
 	// while the consumer uses the indexer to index those files.
-	scanCmd.prepareScanTasks(fileProducerConsumer, indexedFileProducerConsumer, resultsArr, fileProducerErrorsQueue, indexedFileProducerErrorsQueue, xrayVersion)
+	scanCmd.prepareScanTasks(fileProducerConsumer, indexedFileProducerConsumer, resultsArr, cachedResultsArr, fileProducerErrorsQueue, indexedFileProducerErrorsQueue, xrayVersion)
 	scanCmd.performScanTasks(fileProducerConsumer, indexedFileProducerConsumer)
 
 	// Handle results
-	scanCmd.scanPassed = true
 	flatResults := []services.ScanResponse{}
 	for _, arr := range resultsArr {
 		for _, res := range arr {
 			flatResults = append(flatResults, *res)
-			if len(res.Violations) > 0 || len(res.Vulnerabilities) > 0 {
-				// A violation or vulnerability was found, the scan failed.
-				scanCmd.scanPassed = false
-			}
 		}
 	}
-	err = xrutils.PrintScanResults(flatResults, scanCmd.outputFormat == xrutils.Table, scanCmd.includeVulnerabilities, scanCmd.includeLicenses, true)
-	if err != nil {
+	for _, arr := range cachedResultsArr {
+		for _, res := range arr {
+			flatResults = append(flatResults, *res)
+		}
+	}
+	if err = scanCmd.handleResults(flatResults, xrayVersion); err != nil {
 		return err
 	}
 	// If includeVulnerabilities is false it means that context was provided, so we need to check for build violations.
@@ -187,21 +309,169 @@ func (scanCmd *ScanCommand) Run() (err error) {
 	return nil
 }
 
+// handleResults determines whether the scan passed, runs the contextual applicability analysis and
+// secret validation phases when enabled, prints the results (annotated with both, where the output
+// format supports it) and updates scanCmd.scanPassed accordingly.
+func (scanCmd *ScanCommand) handleResults(flatResults []services.ScanResponse, xrayVersion string) error {
+	scanCmd.scanPassed = true
+	for _, res := range flatResults {
+		if len(res.Violations) > 0 || len(res.Vulnerabilities) > 0 {
+			// A violation or vulnerability was found, the scan failed.
+			scanCmd.scanPassed = false
+		}
+	}
+	var applicabilityResults applicability.Results
+	if scanCmd.scanJas {
+		applicabilityResults = scanCmd.runApplicabilityScan(flatResults, xrayVersion)
+	}
+	// When enabled, secret validation runs and is logged regardless of which output format is
+	// requested below - it shouldn't take the CycloneDX VEX/SARIF formats disabling it as a side
+	// effect of them rendering their own document instead of going through the default branch.
+	var validatedSecrets []tokenvalidation.ValidatedSecret
+	if scanCmd.validateSecrets {
+		validatedSecrets = scanCmd.validateSecretsForResults(flatResults)
+		tokenvalidation.PrintSummary(validatedSecrets)
+	}
+
+	switch scanCmd.outputFormat {
+	case CycloneDxVexOutputFormat:
+		return scanCmd.printCycloneDxVex(flatResults, applicabilityResults)
+	case SarifOutputFormat:
+		return scanCmd.printSarif(flatResults)
+	}
+
+	if scanCmd.outputFormat != xrutils.Table && (len(applicabilityResults) > 0 || len(validatedSecrets) > 0) {
+		return scanCmd.printAnnotatedJson(flatResults, applicabilityResults, validatedSecrets)
+	}
+	if err := xrutils.PrintScanResults(flatResults, scanCmd.outputFormat == xrutils.Table, scanCmd.includeVulnerabilities, scanCmd.includeLicenses, true); err != nil {
+		return err
+	}
+	// xrutils.PrintScanResults has no notion of applicability, so fold it into the table view as an
+	// immediately-following table of its own instead of leaving it out of Table output entirely.
+	applicability.PrintTable(applicabilityResults)
+	return nil
+}
+
+// annotatedScanResponse is a single flatResults entry's JSON shape, combining its contextual
+// applicability statuses with the outcome of live secret validation so both are visible in JSON
+// output instead of only ever reaching the terminal log.
+type annotatedScanResponse struct {
+	applicability.AnnotatedScanResponse
+	Secrets []tokenvalidation.ValidatedSecret `json:"secrets,omitempty"`
+}
+
+// printAnnotatedJson prints flatResults as JSON with each vulnerability and violation annotated
+// with its CVEs' contextual applicability status and each secret annotated with its live
+// validation status, since xrutils.PrintScanResults has no notion of either and would otherwise
+// silently drop them from JSON output.
+func (scanCmd *ScanCommand) printAnnotatedJson(flatResults []services.ScanResponse, applicabilityResults applicability.Results, validatedSecrets []tokenvalidation.ValidatedSecret) error {
+	annotatedResults := applicability.Annotate(flatResults, scanCmd.targetPathsSnapshot(), applicabilityResults)
+	results := make([]annotatedScanResponse, len(annotatedResults))
+	secretsIdx := 0
+	for i, res := range flatResults {
+		results[i].AnnotatedScanResponse = annotatedResults[i]
+		if len(res.Secrets) > 0 && secretsIdx+len(res.Secrets) <= len(validatedSecrets) {
+			results[i].Secrets = validatedSecrets[secretsIdx : secretsIdx+len(res.Secrets)]
+			secretsIdx += len(res.Secrets)
+		}
+	}
+	content, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	fmt.Println(string(content))
+	return nil
+}
+
+// printCycloneDxVex renders flatResults as a CycloneDX VEX document and prints it to stdout.
+func (scanCmd *ScanCommand) printCycloneDxVex(flatResults []services.ScanResponse, applicabilityResults applicability.Results) error {
+	content, err := exportformat.RenderCycloneDXVEX(flatResults, scanCmd.targetPathsSnapshot(), applicabilityResults)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(content))
+	return nil
+}
+
+// printSarif renders flatResults as a SARIF log and prints it to stdout.
+func (scanCmd *ScanCommand) printSarif(flatResults []services.ScanResponse) error {
+	content, err := exportformat.RenderSARIF(flatResults, scanCmd.targetPathsSnapshot())
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(content))
+	return nil
+}
+
+// targetPathsSnapshot copies the scan-id-to-artifact-path map accumulated during scanning into a
+// plain map, for callers that don't need sync.Map's concurrency guarantees.
+func (scanCmd *ScanCommand) targetPathsSnapshot() map[string]string {
+	targetPaths := make(map[string]string)
+	scanCmd.targetPaths.Range(func(key, value interface{}) bool {
+		targetPaths[key.(string)] = value.(string)
+		return true
+	})
+	return targetPaths
+}
+
+// validateSecretsForResults follows up on every secret/token the indexer reported with a live,
+// low-privilege API call to the issuing service, so the results can be triaged by which leaks are
+// still active instead of treating every match as equally urgent. The returned slice is aligned
+// with flatResults' own nested secret order, so callers can re-walk flatResults to attribute each
+// validated secret back to the response (and file) it came from.
+func (scanCmd *ScanCommand) validateSecretsForResults(flatResults []services.ScanResponse) []tokenvalidation.ValidatedSecret {
+	var findings []tokenvalidation.SecretFinding
+	for _, res := range flatResults {
+		for _, secret := range res.Secrets {
+			findings = append(findings, tokenvalidation.SecretFinding{
+				RuleId:   secret.RuleId,
+				FilePath: secret.File,
+				Value:    secret.Value,
+			})
+		}
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+	return tokenvalidation.ValidateAll(findings)
+}
+
+// runApplicabilityScan runs the contextual applicability analysis phase and returns its results so
+// output formats that want to factor applicability into their own report (e.g. the CycloneDX VEX
+// analysis.state, the annotated JSON, or the Table output's trailing applicability table) can reuse
+// them. It's best-effort: an entitlement or analyzer-manager failure is logged and otherwise
+// ignored, so a JAS hiccup never fails a scan that Xray itself completed successfully.
+func (scanCmd *ScanCommand) runApplicabilityScan(flatResults []services.ScanResponse, xrayVersion string) applicability.Results {
+	targetPaths := scanCmd.targetPathsSnapshot()
+	if len(targetPaths) == 0 {
+		// An SBOM-sourced scan (or any other scan with no locally indexed artifacts) has nothing on
+		// disk for the analyzer-manager to unpack, so there's no target to compute applicability
+		// for - skip the phase instead of downloading the analyzer-manager for no reason.
+		return nil
+	}
+	results, err := applicability.Scan(flatResults, targetPaths, scanCmd.serverDetails, xrayVersion)
+	if err != nil {
+		log.Warn(fmt.Sprintf("Contextual applicability analysis skipped: %s", err.Error()))
+		return nil
+	}
+	return results
+}
+
 func NewScanCommand() *ScanCommand {
-	return &ScanCommand{}
+	return &ScanCommand{scanJas: true, progress: NewTerminalProgressReporter()}
 }
 
 func (scanCmd *ScanCommand) CommandName() string {
 	return "xr_scan"
 }
 
-func (scanCmd *ScanCommand) prepareScanTasks(fileProducer, indexedFileProducer parallel.Runner, resultsArr [][]*services.ScanResponse, fileErrorsQueue, indexedFileErrorsQueue *clientutils.ErrorsQueue, xrayVersion string) {
+func (scanCmd *ScanCommand) prepareScanTasks(fileProducer, indexedFileProducer parallel.Runner, resultsArr, cachedResultsArr [][]*services.ScanResponse, fileErrorsQueue, indexedFileErrorsQueue *clientutils.ErrorsQueue, xrayVersion string) {
 	go func() {
 		defer fileProducer.Done()
 		// Iterate over file-spec groups and produce indexing tasks.
 		// When encountering an error, log and move to next group.
 		for _, fileGroup := range scanCmd.spec.Files {
-			artifactHandlerFunc := scanCmd.createIndexerHandlerFunc(&fileGroup, indexedFileProducer, resultsArr, indexedFileErrorsQueue, xrayVersion)
+			artifactHandlerFunc := scanCmd.createIndexerHandlerFunc(&fileGroup, indexedFileProducer, resultsArr, cachedResultsArr, indexedFileErrorsQueue, xrayVersion)
 			taskHandler := getAddTaskToProducerFunc(fileProducer, fileErrorsQueue, artifactHandlerFunc)
 
 			err := collectFilesForIndexing(fileGroup, taskHandler)
@@ -213,19 +483,40 @@ func (scanCmd *ScanCommand) prepareScanTasks(fileProducer, indexedFileProducer p
 	}()
 }
 
-func (scanCmd *ScanCommand) createIndexerHandlerFunc(file *spec.File, indexedFileProducer parallel.Runner, resultsArr [][]*services.ScanResponse, errorsQueue *clientutils.ErrorsQueue, xrayVersion string) FileContext {
+func (scanCmd *ScanCommand) createIndexerHandlerFunc(file *spec.File, indexedFileProducer parallel.Runner, resultsArr, cachedResultsArr [][]*services.ScanResponse, errorsQueue *clientutils.ErrorsQueue, xrayVersion string) FileContext {
 	return func(filePath string) parallel.TaskFunc {
 		return func(threadId int) (err error) {
 			logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, false)
+
+			sha256Sum, err := fileSha256(filePath)
+			if err != nil {
+				scanCmd.progress.Error(filePath, err)
+				return err
+			}
+			if cachedResult, ok := scanCmd.checkpoint.completedResult(filePath, sha256Sum); ok {
+				log.Info(logMsgPrefix+"Skipping already-scanned file (--resume):", filePath)
+				scanCmd.progress.UpdateFile(filePath)
+				scanCmd.targetPaths.Store(cachedResult.ScanId, filePath)
+				// Written into cachedResultsArr (this pool's own per-thread slot), not resultsArr -
+				// the indexed-file-producer pool below also writes resultsArr[threadId], under its
+				// own independent threadId numbering, so sharing a slice here would race.
+				cachedResultsArr[threadId] = append(cachedResultsArr[threadId], cachedResult)
+				scanCmd.progress.FinishFile(filePath)
+				return nil
+			}
+
 			log.Info(logMsgPrefix+"Indexing file:", filePath)
 			graph, err := scanCmd.indexFile(filePath)
 			if err != nil {
+				scanCmd.progress.Error(filePath, err)
 				return err
 			}
+			scanCmd.progress.UpdateFile(filePath)
 			// In case of empty graph returned by the indexer,
 			// for instance due to unsupported file format, continue without sending a
 			// graph request to Xray.
 			if graph.Id == "" {
+				scanCmd.progress.FinishFile(filePath)
 				return nil
 			}
 			// Add a new task to the second producer/consumer
@@ -241,9 +532,16 @@ func (scanCmd *ScanCommand) createIndexerHandlerFunc(file *spec.File, indexedFil
 				scanResults, err := commands.RunScanGraphAndGetResults(scanCmd.serverDetails, params, scanCmd.includeVulnerabilities, scanCmd.includeLicenses, xrayVersion)
 				if err != nil {
 					log.Error(fmt.Sprintf("Scanning %s failed with error: %s", graph.Id, err.Error()))
+					scanCmd.progress.Error(filePath, err)
 					return
 				}
+				scanCmd.targetPaths.Store(scanResults.ScanId, filePath)
 				resultsArr[threadId] = append(resultsArr[threadId], scanResults)
+				scanCmd.progress.FinishFile(filePath)
+				if err = scanCmd.checkpoint.markCompleted(filePath, sha256Sum, scanResults); err != nil {
+					log.Warn(fmt.Sprintf("Failed persisting checkpoint for %s: %s", filePath, err.Error()))
+					err = nil
+				}
 				return
 			}
 
@@ -338,4 +636,4 @@ func getXrayRepoPathFromTarget(target string) (repoPath string) {
 		return target
 	}
 	return target[:strings.LastIndex(target, "/")+1]
-}
\ No newline at end of file
+}
@@ -0,0 +1,127 @@
+// Package exportformat renders Xray scan results into downstream-tooling formats - CycloneDX VEX
+// for dependency-tracking systems and SARIF for code-scanning systems - as an alternative to the
+// human-oriented table/JSON xrutils.PrintScanResults already produces.
+package exportformat
+
+import (
+	"encoding/json"
+
+	"github.com/jfrog/jfrog-cli-core/v2/jas/applicability"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+const cycloneDxSpecVersion = "1.4"
+
+// cycloneDxVexDocument is the subset of the CycloneDX 1.4 VEX schema this package populates: a
+// standalone vulnerabilities list with no accompanying component BOM.
+type cycloneDxVexDocument struct {
+	BomFormat       string                `json:"bomFormat"`
+	SpecVersion     string                `json:"specVersion"`
+	Version         int                   `json:"version"`
+	Vulnerabilities []cycloneDxVexFinding `json:"vulnerabilities"`
+}
+
+type cycloneDxVexFinding struct {
+	Id       string               `json:"id"`
+	Source   cycloneDxVexSource   `json:"source"`
+	Ratings  []cycloneDxVexRating `json:"ratings,omitempty"`
+	Analysis cycloneDxVexAnalysis `json:"analysis"`
+	Affects  []cycloneDxVexAffect `json:"affects"`
+}
+
+type cycloneDxVexSource struct {
+	Name string `json:"name"`
+}
+
+type cycloneDxVexRating struct {
+	Severity string `json:"severity"`
+	Vector   string `json:"vector,omitempty"`
+}
+
+type cycloneDxVexAnalysis struct {
+	State string `json:"state"`
+}
+
+type cycloneDxVexAffect struct {
+	Ref string `json:"ref"`
+}
+
+// RenderCycloneDXVEX builds a CycloneDX VEX document out of a set of scan responses. targetPaths
+// maps a scan id to the artifact it was scanned from, and applicabilityResults optionally supplies
+// contextual applicability statuses (keyed the same way applicability.Scan returns them) so
+// analysis.state can reflect reachability, not just raw severity.
+func RenderCycloneDXVEX(flatResults []services.ScanResponse, targetPaths map[string]string, applicabilityResults applicability.Results) ([]byte, error) {
+	doc := cycloneDxVexDocument{
+		BomFormat:   "CycloneDX",
+		SpecVersion: cycloneDxSpecVersion,
+		Version:     1,
+	}
+	for _, scanResponse := range flatResults {
+		target := targetPaths[scanResponse.ScanId]
+		cveStatuses := applicabilityResults[target]
+		for _, vuln := range scanResponse.Vulnerabilities {
+			doc.Vulnerabilities = append(doc.Vulnerabilities, cycloneDxFindingsFor(vuln.Cves, vuln.Severity, vuln.Components, cveStatuses)...)
+		}
+		for _, violation := range scanResponse.Violations {
+			doc.Vulnerabilities = append(doc.Vulnerabilities, cycloneDxFindingsFor(violation.Cves, violation.Severity, violation.Components, cveStatuses)...)
+		}
+	}
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return content, nil
+}
+
+func cycloneDxFindingsFor(cves []services.Cve, severity string, components map[string]services.Component, cveStatuses applicability.CveApplicability) []cycloneDxVexFinding {
+	var findings []cycloneDxVexFinding
+	for _, cve := range cves {
+		if cve.Id == "" {
+			continue
+		}
+		var affects []cycloneDxVexAffect
+		for componentId := range components {
+			affects = append(affects, cycloneDxVexAffect{Ref: componentId})
+		}
+		findings = append(findings, cycloneDxVexFinding{
+			Id:     cve.Id,
+			Source: cycloneDxVexSource{Name: "JFrog Xray"},
+			Ratings: []cycloneDxVexRating{{
+				Severity: severity,
+				Vector:   preferredCvssVector(cve),
+			}},
+			Analysis: cycloneDxVexAnalysis{State: analysisState(severity, cveStatuses[cve.Id])},
+			Affects:  affects,
+		})
+	}
+	return findings
+}
+
+// preferredCvssVector prefers the CVSS v3 vector when Xray reported one, falling back to v2.
+func preferredCvssVector(cve services.Cve) string {
+	if cve.CvssV3Vector != "" {
+		return cve.CvssV3Vector
+	}
+	return cve.CvssV2Vector
+}
+
+// analysisState maps an Xray severity and an optional contextual-applicability status onto a
+// CycloneDX VEX analysis.state. Applicability, when available, takes precedence over severity
+// since it reflects whether the vulnerable code path is actually reachable.
+func analysisState(severity string, applicabilityStatus applicability.Status) string {
+	switch applicabilityStatus {
+	case applicability.NotApplicable:
+		return "not_affected"
+	case applicability.Applicable:
+		return "exploitable"
+	}
+	switch severity {
+	case "Critical", "High":
+		return "exploitable"
+	case "":
+		return "in_triage"
+	default:
+		return "in_triage"
+	}
+}
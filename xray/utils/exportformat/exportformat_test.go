@@ -0,0 +1,70 @@
+package exportformat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/v2/jas/applicability"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func sampleScanResponse() services.ScanResponse {
+	return services.ScanResponse{
+		ScanId: "scan-1",
+		Vulnerabilities: []services.Vulnerability{
+			{
+				Summary:  "Remote code execution in libfoo",
+				Severity: "High",
+				Cves: []services.Cve{
+					{Id: "CVE-2023-1234", CvssV3Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"},
+				},
+				Components: map[string]services.Component{
+					"gav://org.example:libfoo:1.2.3": {},
+				},
+			},
+		},
+		Violations: []services.Violation{
+			{
+				Summary:  "Denial of service in libbar",
+				Severity: "Medium",
+				Cves: []services.Cve{
+					{Id: "CVE-2022-5678"},
+				},
+				Components: map[string]services.Component{
+					"gav://org.example:libbar:4.5.6": {},
+				},
+			},
+		},
+	}
+}
+
+func goldenFile(t *testing.T, name string, actual []byte) {
+	path := filepath.Join("testdata", name)
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		assert.NoError(t, os.WriteFile(path, actual, 0644))
+	}
+	expected, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+}
+
+func TestRenderCycloneDXVEX(t *testing.T) {
+	targetPaths := map[string]string{"scan-1": "/tmp/app.jar"}
+	applicabilityResults := applicability.Results{
+		"/tmp/app.jar": applicability.CveApplicability{
+			"CVE-2023-1234": applicability.NotApplicable,
+		},
+	}
+	content, err := RenderCycloneDXVEX([]services.ScanResponse{sampleScanResponse()}, targetPaths, applicabilityResults)
+	assert.NoError(t, err)
+	goldenFile(t, "cyclonedx_vex_golden.json", content)
+}
+
+func TestRenderSARIF(t *testing.T) {
+	targetPaths := map[string]string{"scan-1": "/tmp/app.jar"}
+	content, err := RenderSARIF([]services.ScanResponse{sampleScanResponse()}, targetPaths)
+	assert.NoError(t, err)
+	goldenFile(t, "sarif_golden.json", content)
+}
@@ -0,0 +1,131 @@
+package exportformat
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/xray/services"
+)
+
+const sarifSchemaUri = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// sarifLog is the subset of the SARIF 2.1.0 schema this package populates: one run per scanned
+// artifact, with one result per CVE found in it.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleId              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+// RenderSARIF builds a SARIF log with one run per scanned artifact. targetPaths maps a scan id to
+// the local path of the artifact it was scanned from, used as the results' artifact location. Each
+// result's partialFingerprints combine the component id and CVE id, so they stay stable across runs
+// for GitHub code-scanning de-duplication.
+func RenderSARIF(flatResults []services.ScanResponse, targetPaths map[string]string) ([]byte, error) {
+	log := sarifLog{Schema: sarifSchemaUri, Version: "2.1.0"}
+	for _, scanResponse := range flatResults {
+		log.Runs = append(log.Runs, sarifRunFor(scanResponse, targetPaths[scanResponse.ScanId]))
+	}
+	content, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return content, nil
+}
+
+func sarifRunFor(scanResponse services.ScanResponse, targetPath string) sarifRun {
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: "JFrog Xray"}}}
+	seenRules := make(map[string]bool)
+
+	addResult := func(componentId string, cve services.Cve, severity, summary string) {
+		if cve.Id == "" {
+			return
+		}
+		if !seenRules[cve.Id] {
+			seenRules[cve.Id] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{Id: cve.Id})
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleId:  cve.Id,
+			Level:   sarifLevel(severity),
+			Message: sarifMessage{Text: summary},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{Uri: targetPath}},
+			}},
+			PartialFingerprints: map[string]string{
+				"xrayFingerprint": fmt.Sprintf("%s:%s", componentId, cve.Id),
+			},
+		})
+	}
+
+	for _, vuln := range scanResponse.Vulnerabilities {
+		for componentId := range vuln.Components {
+			for _, cve := range vuln.Cves {
+				addResult(componentId, cve, vuln.Severity, vuln.Summary)
+			}
+		}
+	}
+	for _, violation := range scanResponse.Violations {
+		for componentId := range violation.Components {
+			for _, cve := range violation.Cves {
+				addResult(componentId, cve, violation.Severity, violation.Summary)
+			}
+		}
+	}
+	return run
+}
+
+// sarifLevel maps an Xray severity onto the SARIF result levels GitHub code scanning understands.
+func sarifLevel(severity string) string {
+	switch severity {
+	case "Critical", "High":
+		return "error"
+	case "Medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
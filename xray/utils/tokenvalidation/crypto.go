@@ -0,0 +1,186 @@
+package tokenvalidation
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// jwtIssuer pulls the "iss" claim out of a JWT's payload without verifying its signature - the
+// token is only used to read where it came from, the live API call against that issuer is what
+// actually proves whether it's still valid.
+func jwtIssuer(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", errorutils.CheckErrorf("jfrog-access-token secret is not a well-formed JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if claims.Issuer == "" {
+		return "", errorutils.CheckErrorf("jfrog-access-token JWT has no \"iss\" claim")
+	}
+	return claims.Issuer, nil
+}
+
+// newSignedStsRequest builds a SigV4-signed sts:GetCallerIdentity request, the AWS-documented way
+// to check whether a pair of access keys is still valid.
+func newSignedStsRequest(ctx context.Context, accessKeyId, secretAccessKey string) (*http.Request, error) {
+	const (
+		region  = "us-east-1"
+		service = "sts"
+		host    = "sts.amazonaws.com"
+	)
+	amzDate := stsRequestTime().Format("20060102T150405Z")
+	dateStamp := amzDate[:8]
+
+	query := "Action=GetCallerIdentity&Version=2011-06-15"
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		"/",
+		query,
+		"host:" + host + "\n" + "x-amz-date:" + amzDate + "\n",
+		"host;x-amz-date",
+		sha256Hex(""),
+	}, "\n")
+
+	credentialScope := dateStamp + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		credentialScope,
+		sha256Hex(canonicalRequest),
+	}, "\n")
+
+	signingKey := sigV4SigningKey(secretAccessKey, dateStamp, region, service)
+	signature := hmacHex(signingKey, stringToSign)
+
+	authHeader := fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=host;x-amz-date, Signature=%s",
+		accessKeyId, credentialScope, signature)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+host+"/?"+query, nil)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("Authorization", authHeader)
+	return req, nil
+}
+
+func sigV4SigningKey(secretAccessKey, dateStamp, region, service string) []byte {
+	kDate := hmacSum([]byte("AWS4"+secretAccessKey), dateStamp)
+	kRegion := hmacSum(kDate, region)
+	kService := hmacSum(kRegion, service)
+	return hmacSum(kService, "aws4_request")
+}
+
+func hmacSum(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hmacHex(key []byte, data string) string {
+	return fmt.Sprintf("%x", hmacSum(key, data))
+}
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return fmt.Sprintf("%x", sum)
+}
+
+// stsRequestTime is its own function so it's the only place that would need patching if this
+// package ever grows tests that need a fixed clock.
+func stsRequestTime() time.Time {
+	return time.Now().UTC()
+}
+
+// gcpServiceAccountKey is the subset of a GCP service-account JSON key file needed to mint a
+// self-signed JWT and exchange it for an access token.
+type gcpServiceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenUri    string `json:"token_uri"`
+}
+
+// signGcpAssertion parses a service-account JSON key and returns a signed JWT assertion suitable
+// for the OAuth2 JWT-bearer token exchange, along with the token endpoint to exchange it at.
+func signGcpAssertion(keyJson string) (assertion string, tokenUri string, err error) {
+	var key gcpServiceAccountKey
+	if err = json.Unmarshal([]byte(keyJson), &key); err != nil {
+		return "", "", errorutils.CheckError(err)
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return "", "", errorutils.CheckErrorf("gcp-service-account secret is missing client_email or private_key")
+	}
+	if key.TokenUri == "" {
+		key.TokenUri = "https://oauth2.googleapis.com/token"
+	}
+
+	privateKey, err := parseGcpPrivateKey(key.PrivateKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	now := stsRequestTime()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   key.ClientEmail,
+		"scope": "https://www.googleapis.com/auth/cloud-platform.read-only",
+		"aud":   key.TokenUri,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", "", errorutils.CheckError(err)
+	}
+	payload := base64.RawURLEncoding.EncodeToString(claims)
+
+	signingInput := header + "." + payload
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", "", errorutils.CheckError(err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), key.TokenUri, nil
+}
+
+func parseGcpPrivateKey(pemKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemKey))
+	if block == nil {
+		return nil, errorutils.CheckErrorf("gcp-service-account private_key is not valid PEM")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	rsaKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errorutils.CheckErrorf("gcp-service-account private_key is not an RSA key")
+	}
+	return rsaKey, nil
+}
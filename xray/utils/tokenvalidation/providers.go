@@ -0,0 +1,141 @@
+package tokenvalidation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// githubValidator checks a GitHub personal access token by calling the authenticated user
+// endpoint, the lowest-privilege request the GitHub API offers.
+type githubValidator struct{}
+
+func (githubValidator) Validate(ctx context.Context, client *http.Client, secret SecretFinding) (Status, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/user", nil)
+	if err != nil {
+		return Unknown, errorutils.CheckError(err)
+	}
+	req.Header.Set("Authorization", "token "+secret.Value)
+	return statusFromResponse(client, req)
+}
+
+// slackValidator checks a Slack token by calling auth.test, which Slack documents as safe to call
+// purely to verify a token without touching any workspace data.
+type slackValidator struct{}
+
+func (slackValidator) Validate(ctx context.Context, client *http.Client, secret SecretFinding) (Status, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://slack.com/api/auth.test", nil)
+	if err != nil {
+		return Unknown, errorutils.CheckError(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+secret.Value)
+	resp, err := client.Do(req)
+	if err != nil {
+		return Unknown, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	var body struct {
+		Ok bool `json:"ok"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Unknown, errorutils.CheckError(err)
+	}
+	if body.Ok {
+		return Active, nil
+	}
+	return Inactive, nil
+}
+
+// jfrogValidator checks a JFrog access token against the issuing platform's own "current token"
+// endpoint, derived from the "iss" claim embedded in the token itself.
+type jfrogValidator struct{}
+
+func (jfrogValidator) Validate(ctx context.Context, client *http.Client, secret SecretFinding) (Status, error) {
+	issuer, err := jwtIssuer(secret.Value)
+	if err != nil {
+		return Unknown, err
+	}
+	url := strings.TrimRight(issuer, "/") + "/access/api/v1/tokens/current"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Unknown, errorutils.CheckError(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+secret.Value)
+	return statusFromResponse(client, req)
+}
+
+// awsValidator checks an AWS access key by calling sts:GetCallerIdentity, the standard
+// low-privilege way to confirm a key is still live without granting it any resource access. It
+// expects secret.Value to already be a colon-joined "id:secret" pair - pairAwsSecrets builds that
+// from the access-key-id and secret-access-key findings the indexer reports separately before this
+// validator ever runs.
+type awsValidator struct{}
+
+func (awsValidator) Validate(ctx context.Context, client *http.Client, secret SecretFinding) (Status, error) {
+	accessKeyId, secretAccessKey, found := strings.Cut(secret.Value, ":")
+	if !found {
+		return Unknown, errorutils.CheckErrorf("aws-access-key-id secret has no matching secret access key in the same file")
+	}
+	req, err := newSignedStsRequest(ctx, accessKeyId, secretAccessKey)
+	if err != nil {
+		return Unknown, err
+	}
+	return statusFromResponse(client, req)
+}
+
+// gcpValidator checks a GCP service-account key by exchanging it for an OAuth2 access token. The
+// token requested is limited to the read-only cloud-platform scope and is never used beyond
+// proving the key still works.
+type gcpValidator struct{}
+
+func (gcpValidator) Validate(ctx context.Context, client *http.Client, secret SecretFinding) (Status, error) {
+	assertion, tokenUri, err := signGcpAssertion(secret.Value)
+	if err != nil {
+		return Unknown, err
+	}
+	form := strings.NewReader("grant_type=urn:ietf:params:oauth:grant-type:jwt-bearer&assertion=" + assertion)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenUri, form)
+	if err != nil {
+		return Unknown, errorutils.CheckError(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req)
+	if err != nil {
+		return Unknown, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode == http.StatusOK {
+		return Active, nil
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusBadRequest {
+		return Inactive, nil
+	}
+	return Unknown, nil
+}
+
+// statusFromResponse maps the common 200/401/403 convention most of these APIs share onto a
+// Status, since an active token authenticates and an inactive or revoked one is rejected.
+func statusFromResponse(client *http.Client, req *http.Request) (Status, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return Unknown, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return Active, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return Inactive, nil
+	default:
+		return Unknown, nil
+	}
+}
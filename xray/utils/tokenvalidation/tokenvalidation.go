@@ -0,0 +1,178 @@
+// Package tokenvalidation performs live, low-privilege API calls against the service a leaked
+// secret belongs to, so scan results can tell a user which of their exposed tokens are still
+// exploitable instead of leaving them to check each one by hand.
+package tokenvalidation
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jfrog/gofrog/parallel"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Status is the outcome of validating a single secret against its issuing service.
+type Status string
+
+const (
+	Active   Status = "Active"
+	Inactive Status = "Inactive"
+	Unknown  Status = "Unknown"
+
+	defaultPoolSize = 10
+	defaultTimeout  = 30 * time.Second
+)
+
+// SecretFinding is a single secret/token the indexer flagged in a scanned artifact.
+type SecretFinding struct {
+	// RuleId is the detector rule id the indexer reported, e.g. "aws-access-key-id".
+	RuleId string
+	// FilePath is the path of the file the secret was found in, as reported by the indexer.
+	FilePath string
+	// Value is the raw matched secret, needed to perform the live validation call.
+	Value string
+}
+
+// ValidatedSecret is a SecretFinding annotated with the outcome of its live validation call.
+type ValidatedSecret struct {
+	SecretFinding
+	Status Status
+	// Err is set when the validation call itself failed (network error, malformed secret, etc.),
+	// as opposed to the call succeeding and reporting the token as inactive.
+	Err error
+}
+
+// Validator performs a live, low-privilege API call to determine whether a secret is still active.
+type Validator interface {
+	Validate(ctx context.Context, client *http.Client, secret SecretFinding) (Status, error)
+}
+
+// awsAccessKeyIdRule and awsSecretAccessKeyRule are the detector rule ids the indexer reports for
+// the two halves of an AWS credential. Each is flagged as its own finding - never a pre-joined
+// "id:secret" value - so pairAwsSecrets has to reassemble them before awsValidator can use either.
+const (
+	awsAccessKeyIdRule     = "aws-access-key-id"
+	awsSecretAccessKeyRule = "aws-secret-access-key"
+)
+
+// registry maps a detector rule id to the validator that knows how to check it live.
+// aws-secret-access-key has no entry: on its own half of a credential pair it can't be validated,
+// so it's left to fall through to the Unknown default, same as any other unregistered rule id.
+var registry = map[string]Validator{
+	"aws-access-key-id":   awsValidator{},
+	"github-pat":          githubValidator{},
+	"slack-token":         slackValidator{},
+	"jfrog-access-token":  jfrogValidator{},
+	"gcp-service-account": gcpValidator{},
+}
+
+// RegisterValidator adds or overrides the validator used for a given detector rule id.
+func RegisterValidator(ruleId string, validator Validator) {
+	registry[ruleId] = validator
+}
+
+// ValidateAll runs every finding's validator concurrently through a bounded pool, respecting a
+// global timeout shared by all in-flight calls. Findings with no registered validator are reported
+// as Unknown rather than skipped, so the summary still accounts for every finding.
+func ValidateAll(findings []SecretFinding) []ValidatedSecret {
+	findings = pairAwsSecrets(findings)
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	client := &http.Client{
+		Timeout:   defaultTimeout,
+		Transport: &http.Transport{Proxy: http.ProxyFromEnvironment},
+	}
+
+	results := make([]ValidatedSecret, len(findings))
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	producer := parallel.NewRunner(defaultPoolSize, len(findings), false)
+	go func() {
+		defer producer.Done()
+		for i, finding := range findings {
+			i, finding := i, finding
+			producer.AddTaskWithError(func(threadId int) error {
+				results[i] = validateOne(ctx, client, finding)
+				return nil
+			}, errorsQueue.AddError)
+		}
+	}()
+	producer.Run()
+	return results
+}
+
+// pairAwsSecrets folds each aws-access-key-id finding's same-file aws-secret-access-key finding
+// into its Value as a colon-joined "id:secret" pair, since the indexer reports every detector match
+// as its own finding rather than a pre-joined credential. Findings are returned in the same order
+// and count as given, so callers that align the result back onto per-response secrets (see
+// ScanCommand.validateSecretsForResults) aren't affected - only the access-key-id finding's Value is
+// rewritten. An access key with no matching secret in the same file is left untouched and falls
+// through awsValidator to Unknown, same as before.
+func pairAwsSecrets(findings []SecretFinding) []SecretFinding {
+	secretKeysByFile := make(map[string][]string)
+	for _, f := range findings {
+		if f.RuleId == awsSecretAccessKeyRule {
+			secretKeysByFile[f.FilePath] = append(secretKeysByFile[f.FilePath], f.Value)
+		}
+	}
+	if len(secretKeysByFile) == 0 {
+		return findings
+	}
+
+	paired := make([]SecretFinding, len(findings))
+	copy(paired, findings)
+	nextIdx := make(map[string]int)
+	for i, f := range paired {
+		if f.RuleId != awsAccessKeyIdRule {
+			continue
+		}
+		keys := secretKeysByFile[f.FilePath]
+		idx := nextIdx[f.FilePath]
+		if idx >= len(keys) {
+			continue
+		}
+		nextIdx[f.FilePath] = idx + 1
+		paired[i].Value = f.Value + ":" + keys[idx]
+	}
+	return paired
+}
+
+func validateOne(ctx context.Context, client *http.Client, finding SecretFinding) ValidatedSecret {
+	validator, ok := registry[finding.RuleId]
+	if !ok {
+		return ValidatedSecret{SecretFinding: finding, Status: Unknown}
+	}
+	status, err := validator.Validate(ctx, client, finding)
+	if err != nil {
+		log.Warn(fmt.Sprintf("Could not validate %s secret in %s: %s", finding.RuleId, finding.FilePath, err.Error()))
+		return ValidatedSecret{SecretFinding: finding, Status: Unknown, Err: err}
+	}
+	return ValidatedSecret{SecretFinding: finding, Status: status}
+}
+
+// PrintSummary logs each validated secret's status, followed by a single line with the counts of
+// active vs. inactive tokens, so users can both triage the truly exploitable leaks first and see
+// exactly which finding each status belongs to.
+func PrintSummary(results []ValidatedSecret) {
+	if len(results) == 0 {
+		return
+	}
+	var active, inactive, unknown int
+	log.Info("Secret validation:")
+	for _, res := range results {
+		switch res.Status {
+		case Active:
+			active++
+		case Inactive:
+			inactive++
+		default:
+			unknown++
+		}
+		log.Info(fmt.Sprintf("  %-20s %-8s %s", res.RuleId, res.Status, res.FilePath))
+	}
+	log.Info(fmt.Sprintf("Secret validation summary: %d active, %d inactive, %d unknown", active, inactive, unknown))
+}
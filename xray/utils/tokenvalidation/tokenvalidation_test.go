@@ -0,0 +1,125 @@
+package tokenvalidation
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJwtIssuer(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"https://example.jfrog.io"}`))
+	token := "header." + payload + ".signature"
+
+	issuer, err := jwtIssuer(token)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.jfrog.io", issuer)
+}
+
+func TestJwtIssuer_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"not a jwt", "not-a-jwt"},
+		{"malformed payload", "header.not-base64!.signature"},
+		{"missing iss claim", "header." + base64.RawURLEncoding.EncodeToString([]byte(`{}`)) + ".signature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jwtIssuer(tt.token)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSigV4SigningKey_Deterministic(t *testing.T) {
+	key1 := sigV4SigningKey("secret", "20240101", "us-east-1", "sts")
+	key2 := sigV4SigningKey("secret", "20240101", "us-east-1", "sts")
+	assert.Equal(t, key1, key2)
+
+	key3 := sigV4SigningKey("other-secret", "20240101", "us-east-1", "sts")
+	assert.NotEqual(t, key1, key3)
+}
+
+func TestSignGcpAssertion_MissingFields(t *testing.T) {
+	_, _, err := signGcpAssertion(`{"private_key":"x"}`)
+	assert.Error(t, err)
+
+	_, _, err = signGcpAssertion(`{"client_email":"x@y.iam.gserviceaccount.com"}`)
+	assert.Error(t, err)
+}
+
+func TestSignGcpAssertion_InvalidKeyJson(t *testing.T) {
+	_, _, err := signGcpAssertion("not json")
+	assert.Error(t, err)
+}
+
+func TestParseGcpPrivateKey_InvalidPem(t *testing.T) {
+	_, err := parseGcpPrivateKey("not a pem block")
+	assert.Error(t, err)
+}
+
+// fakeValidator is a test double that reports a fixed status without making any network calls.
+type fakeValidator struct {
+	status Status
+	err    error
+}
+
+func (f fakeValidator) Validate(_ context.Context, _ *http.Client, _ SecretFinding) (Status, error) {
+	return f.status, f.err
+}
+
+func TestValidateAll_UnknownRuleId(t *testing.T) {
+	results := ValidateAll([]SecretFinding{{RuleId: "not-a-registered-rule", FilePath: "foo.txt"}})
+	require.Len(t, results, 1)
+	assert.Equal(t, Unknown, results[0].Status)
+	assert.NoError(t, results[0].Err)
+}
+
+func TestValidateAll_UsesRegisteredValidator(t *testing.T) {
+	RegisterValidator("test-rule-active", fakeValidator{status: Active})
+	defer delete(registry, "test-rule-active")
+
+	results := ValidateAll([]SecretFinding{{RuleId: "test-rule-active", FilePath: "foo.txt", Value: "secret"}})
+	require.Len(t, results, 1)
+	assert.Equal(t, Active, results[0].Status)
+}
+
+func TestPairAwsSecrets_JoinsSameFilePair(t *testing.T) {
+	findings := []SecretFinding{
+		{RuleId: awsAccessKeyIdRule, FilePath: "a.env", Value: "AKIAEXAMPLE"},
+		{RuleId: awsSecretAccessKeyRule, FilePath: "a.env", Value: "shhh"},
+	}
+
+	paired := pairAwsSecrets(findings)
+	require.Len(t, paired, 2)
+	assert.Equal(t, "AKIAEXAMPLE:shhh", paired[0].Value)
+	assert.Equal(t, "shhh", paired[1].Value)
+}
+
+func TestPairAwsSecrets_NoMatchInOtherFile(t *testing.T) {
+	findings := []SecretFinding{
+		{RuleId: awsAccessKeyIdRule, FilePath: "a.env", Value: "AKIAEXAMPLE"},
+		{RuleId: awsSecretAccessKeyRule, FilePath: "b.env", Value: "shhh"},
+	}
+
+	paired := pairAwsSecrets(findings)
+	require.Len(t, paired, 2)
+	assert.Equal(t, "AKIAEXAMPLE", paired[0].Value)
+}
+
+func TestValidateAll_ValidatorError(t *testing.T) {
+	validationErr := assert.AnError
+	RegisterValidator("test-rule-error", fakeValidator{status: Unknown, err: validationErr})
+	defer delete(registry, "test-rule-error")
+
+	results := ValidateAll([]SecretFinding{{RuleId: "test-rule-error", FilePath: "foo.txt", Value: "secret"}})
+	require.Len(t, results, 1)
+	assert.Equal(t, Unknown, results[0].Status)
+	assert.Equal(t, validationErr, results[0].Err)
+}